@@ -3,12 +3,20 @@ package config
 import (
     "log"
     "os"
+    "strconv"
+    "strings"
+    "time"
 )
 
 type Config struct {
     // Server
     Port string
 
+    // Backend selects the JobStore/MessageQueue implementation: "aws"
+    // (DynamoDB + SQS, default) or "nats_pg" (Postgres + NATS JetStream), so
+    // the system can run locally or on-prem without LocalStack.
+    Backend string
+
     // AWS
     AWSRegion string
 
@@ -16,29 +24,109 @@ type Config struct {
     InputBucket  string
     OutputBucket string
 
+    // InputBucketURL and OutputBucketURL are gocloud.dev/blob bucket URLs (e.g.
+    // "s3://...", "gs://...", "azblob://...", "file://..."). They don't have to
+    // share a scheme - main.go's newObjectStore picks a backend per bucket URL,
+    // not once for the whole config - so an input bucket on S3 and an output
+    // bucket on GCS is a supported configuration.
+    InputBucketURL  string
+    OutputBucketURL string
+
+    // Multipart uploads
+    MultipartUploadTTL       time.Duration
+    MultipartJanitorInterval time.Duration
+
     // SQS
     RequestQueueURL string
     StatusQueueURL  string
 
+    // MessageCodec selects the SQSService wire format: "json" (default),
+    // "avro", or "proto".
+    MessageCodec string
+
+    // RequestDLQURL is the dead-letter queue a redrive policy on StatusQueueURL
+    // moves messages to once they exceed MaxReceiveCount deliveries. The DLQ
+    // poller drains it and marks the offending S3Key permanently failed.
+    RequestDLQURL   string
+    MaxReceiveCount int
+
+    // Retry backoff applied to status messages between failed attempts, before
+    // MaxReceiveCount is reached and the redrive policy takes over.
+    RetryInitialBackoff time.Duration
+    RetryMaxBackoff     time.Duration
+    RetryMultiplier     float64
+    RetryJitter         time.Duration
+
     // DynamoDB
     TableName string
+    TableArn  string // optional; resolved via DescribeTable if empty
+
+    // Archival - nightly DynamoDB PITR export of the Jobs table to S3.
+    // Only meaningful for Backend == "aws": Postgres has no PITR-export
+    // equivalent here, so archival is a no-op under "nats_pg".
+    ArchiveEnabled   bool
+    ArchiveInterval  time.Duration
+    ArchiveRetention time.Duration
+
+    // Postgres + NATS JetStream, used when Backend == "nats_pg".
+    DatabaseURL    string
+    NATSURL        string
+    NATSStreamName string
 }
 
 func LoadConfig() *Config {
     cfg := &Config{
         Port:      getEnv("PORT", "8080"),
+        Backend:   getEnv("BACKEND", "aws"),
         AWSRegion: getEnv("AWS_REGION", "us-east-1"),
 
         // S3 Buckets
         InputBucket:  getEnv("INPUT_BUCKET", "distributed-classifier-input"),
         OutputBucket: getEnv("OUTPUT_BUCKET", "distributed-classifier-output"),
 
+        // Object store backend - defaults to AWS S3 for backwards compatibility
+        InputBucketURL:  getEnv("INPUT_BUCKET_URL", ""),
+        OutputBucketURL: getEnv("OUTPUT_BUCKET_URL", ""),
+
+        // Multipart uploads
+        MultipartUploadTTL:       getEnvDuration("MULTIPART_UPLOAD_TTL", 24*time.Hour),
+        MultipartJanitorInterval: getEnvDuration("MULTIPART_JANITOR_INTERVAL", 1*time.Hour),
+
         // SQS Queues
         RequestQueueURL: getEnv("REQUEST_QUEUE_URL", ""),
         StatusQueueURL:  getEnv("STATUS_QUEUE_URL", ""),
+        RequestDLQURL:   getEnv("REQUEST_DLQ_URL", ""),
+        MaxReceiveCount: getEnvInt("MAX_RECEIVE_COUNT", 3),
+        MessageCodec:    getEnv("MESSAGE_CODEC", "json"),
+
+        RetryInitialBackoff: getEnvDuration("RETRY_INITIAL_BACKOFF", 2*time.Second),
+        RetryMaxBackoff:     getEnvDuration("RETRY_MAX_BACKOFF", 5*time.Minute),
+        RetryMultiplier:     getEnvFloat("RETRY_MULTIPLIER", 4),
+        RetryJitter:         getEnvDuration("RETRY_JITTER", 0),
 
         // DynamoDB
         TableName: getEnv("DYNAMODB_TABLE", "classification-jobs"),
+        TableArn:  getEnv("DYNAMODB_TABLE_ARN", ""),
+
+        // Archival
+        ArchiveEnabled:   getEnvBool("ARCHIVE_ENABLED", false),
+        ArchiveInterval:  getEnvDuration("ARCHIVE_INTERVAL", 24*time.Hour),
+        ArchiveRetention: getEnvDuration("ARCHIVE_RETENTION", 90*24*time.Hour),
+
+        // Postgres + NATS JetStream (BACKEND=nats_pg)
+        DatabaseURL:    getEnv("DATABASE_URL", ""),
+        NATSURL:        getEnv("NATS_URL", "nats://127.0.0.1:4222"),
+        NATSStreamName: getEnv("NATS_STREAM_NAME", "classification"),
+    }
+
+    // Translate the legacy bucket-name variables into s3:// URLs so the rest of
+    // the pipeline can always deal in bucket URLs, regardless of which backend
+    // INPUT_BUCKET_URL/OUTPUT_BUCKET_URL actually point at.
+    if cfg.InputBucketURL == "" {
+        cfg.InputBucketURL = "s3://" + cfg.InputBucket
+    }
+    if cfg.OutputBucketURL == "" {
+        cfg.OutputBucketURL = "s3://" + cfg.OutputBucket
     }
 
     // Validate required config
@@ -49,9 +137,86 @@ func LoadConfig() *Config {
     return cfg
 }
 
+// BucketURLScheme extracts the driver scheme ("s3", "gs", "azblob", "file", ...)
+// from a gocloud.dev/blob bucket URL such as "gs://my-bucket". A bare bucket
+// name with no "scheme://" prefix is treated as "s3" for backwards
+// compatibility with the legacy INPUT_BUCKET/OUTPUT_BUCKET vars.
+func BucketURLScheme(bucketURL string) string {
+    if idx := strings.Index(bucketURL, "://"); idx != -1 {
+        return bucketURL[:idx]
+    }
+    return "s3"
+}
+
+// IsS3BucketURL reports whether bucketURL should be served by the native
+// aws.S3Service rather than the generic gocloud.dev/blob driver.
+func IsS3BucketURL(bucketURL string) bool {
+    return BucketURLScheme(bucketURL) == "s3"
+}
+
 func getEnv(key, defaultValue string) string {
     if value := os.Getenv(key); value != "" {
         return value
     }
     return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+
+    parsed, err := strconv.ParseBool(value)
+    if err != nil {
+        log.Printf("Invalid bool for %s=%q, using default %t", key, value, defaultValue)
+        return defaultValue
+    }
+
+    return parsed
+}
+
+func getEnvInt(key string, defaultValue int) int {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+
+    parsed, err := strconv.Atoi(value)
+    if err != nil {
+        log.Printf("Invalid int for %s=%q, using default %d", key, value, defaultValue)
+        return defaultValue
+    }
+
+    return parsed
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+
+    parsed, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        log.Printf("Invalid float for %s=%q, using default %g", key, value, defaultValue)
+        return defaultValue
+    }
+
+    return parsed
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+
+    seconds, err := strconv.Atoi(value)
+    if err != nil {
+        log.Printf("Invalid duration for %s=%q, using default %s", key, value, defaultValue)
+        return defaultValue
+    }
+
+    return time.Duration(seconds) * time.Second
 }
\ No newline at end of file
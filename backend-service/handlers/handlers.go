@@ -1,39 +1,51 @@
 package handlers
 
 import (
+	"context"
 	"distributed-classifier/backend/config"
 	"distributed-classifier/backend/models"
-	"distributed-classifier/backend/services"
-	"encoding/json"
+	"distributed-classifier/backend/services/archiver"
+	"distributed-classifier/backend/services/backend"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type Handler struct {
-	dynamoSvc *services.DynamoService
-	s3Svc     *services.S3Service
-	sqsSvc    *services.SQSService
-	config    *config.Config
-	mu        sync.Mutex
+	jobStore    backend.JobStore
+	s3Svc       backend.ObjectStore
+	queue       backend.MessageQueue
+	codec       backend.Codec
+	archiverSvc *archiver.Archiver
+	config      *config.Config
+	retryPolicy backend.RetryPolicy
+	mu          sync.Mutex
 }
 
-func NewHandler(dynamo *services.DynamoService, s3 *services.S3Service, sqs *services.SQSService, cfg *config.Config) *Handler {
+func NewHandler(jobStore backend.JobStore, store backend.ObjectStore, queue backend.MessageQueue, codec backend.Codec, archiverSvc *archiver.Archiver, cfg *config.Config) *Handler {
 	return &Handler{
-		dynamoSvc: dynamo,
-		s3Svc:     s3,
-		sqsSvc:    sqs,
-		config:    cfg,
+		jobStore:    jobStore,
+		s3Svc:       store,
+		queue:       queue,
+		codec:       codec,
+		archiverSvc: archiverSvc,
+		config:      cfg,
+		retryPolicy: backend.RetryPolicy{
+			MaxAttempts:    cfg.MaxReceiveCount,
+			InitialBackoff: cfg.RetryInitialBackoff,
+			MaxBackoff:     cfg.RetryMaxBackoff,
+			Multiplier:     cfg.RetryMultiplier,
+			Jitter:         cfg.RetryJitter,
+		},
 	}
 }
 
@@ -74,6 +86,7 @@ func (h *Handler) SubmitJob(c *gin.Context) {
 	// Create job record
 	job := &models.Job{
 		JobID:               jobID,
+		EntityType:          "job",
 		Status:              "pending",
 		JobType:             req.JobType,
 		InputBucket:         h.config.InputBucket,
@@ -87,16 +100,20 @@ func (h *Handler) SubmitJob(c *gin.Context) {
 	}
 
 	// Save to DynamoDB
-	if err := h.dynamoSvc.CreateJob(job); err != nil {
+	if err := h.jobStore.CreateJob(c.Request.Context(), job); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job"})
 		return
 	}
 
 	// Send to SQS - Split requests one by one
-	// Run in goroutine to immediately return JobID to user
+	// Run in goroutine to immediately return JobID to user. This must outlive
+	// the request, so it gets its own background context rather than the
+	// request's, which is cancelled the moment the response above is written.
 	go func() {
+		ctx := context.Background()
+
 		// Update status to queued
-		h.dynamoSvc.UpdateJobStatus(jobID, "queued", "")
+		h.jobStore.UpdateJobStatus(ctx, jobID, "queued", "")
 
 		for _, key := range req.S3Keys {
 			sqsMsg := models.SQSMessage{
@@ -108,21 +125,22 @@ func (h *Handler) SubmitJob(c *gin.Context) {
 				TopK:                req.TopK,
 				ConfidenceThreshold: req.ConfidenceThreshold,
 				RetryCount:          0,
+				SubTaskID:           fmt.Sprintf("%s:%s", jobID, key),
 			}
 
-			if err := h.sqsSvc.SendMessage(h.config.RequestQueueURL, sqsMsg); err != nil {
+			if err := backend.Publish(ctx, h.queue, h.codec, h.config.RequestQueueURL, sqsMsg); err != nil {
 				// Update status to failed
 				// Note: If some succeed and some fail, we might end up in a weird state.
 				// For now, fail the whole job if one fails to enqueue.
 				log.Printf("Failed to send SQS message for JobID: %s, Image: %s, Error: %v", jobID, key, err)
-				h.dynamoSvc.UpdateJobStatus(jobID, "failed", fmt.Sprintf("Failed to queue job for key %s", key))
+				h.jobStore.UpdateJobStatus(ctx, jobID, "failed", fmt.Sprintf("Failed to queue job for key %s", key))
 				// We cannot return HTTP error here as response is already sent
 				return
 			}
 			log.Printf("Sent SQS message for JobID: %s, Image: %s", jobID, key)
 		}
 		// If all messages were successfully sent, update the job status to 'processing'
-		h.dynamoSvc.UpdateJobStatus(jobID, "processing", "")
+		h.jobStore.UpdateJobStatus(ctx, jobID, "processing", "")
 	}()
 
 	c.JSON(http.StatusAccepted, models.SubmitJobResponse{
@@ -136,7 +154,7 @@ func (h *Handler) SubmitJob(c *gin.Context) {
 func (h *Handler) GetJobStatus(c *gin.Context) {
 	jobID := c.Param("jobId")
 
-	job, err := h.dynamoSvc.GetJob(jobID)
+	job, err := h.jobStore.GetJob(c.Request.Context(), jobID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
@@ -158,6 +176,11 @@ func (h *Handler) GetJobStatus(c *gin.Context) {
 		response["retry_count"] = job.RetryCount
 	}
 
+	if job.FailureReason != "" {
+		response["failure_reason"] = job.FailureReason
+		response["attempts"] = job.Attempts
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -165,13 +188,13 @@ func (h *Handler) GetJobStatus(c *gin.Context) {
 func (h *Handler) GetJobResult(c *gin.Context) {
 	jobID := c.Param("jobId")
 
-	job, err := h.dynamoSvc.GetJob(jobID)
+	job, err := h.jobStore.GetJob(c.Request.Context(), jobID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
-	if job.Status != "completed" {
+	if job.Status != "completed" && job.Status != "completed_with_errors" {
 		c.JSON(http.StatusAccepted, gin.H{
 			"job_id":  job.JobID,
 			"status":  job.Status,
@@ -188,29 +211,121 @@ func (h *Handler) GetJobResult(c *gin.Context) {
 	c.JSON(http.StatusOK, job.Result)
 }
 
-// StartStatusListener listens for status updates from ML service
-func (h *Handler) StartStatusListener() {
+// StartStatusListener listens for status updates from ML service. It polls
+// until ctx is cancelled, at which point it finishes processing (and
+// deleting) any batch already received from this poll cycle before
+// returning, so a shutdown can't drop a message that's already off the queue
+// but not yet handled. wg.Done is called once the listener has fully drained.
+func (h *Handler) StartStatusListener(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
 	log.Println("Starting SQS status listener...")
 
 	for {
-		messages, err := h.sqsSvc.ReceiveMessages(h.config.StatusQueueURL)
+		select {
+		case <-ctx.Done():
+			log.Println("Status listener shutting down...")
+			return
+		default:
+		}
+
+		messages, err := h.queue.ReceiveMessages(ctx, h.config.StatusQueueURL)
 		if err != nil {
 			log.Printf("Error receiving messages: %v", err)
 			time.Sleep(5 * time.Second)
 			continue
 		}
 
+		// The batch is handled with a context that shutdown does not cancel, so
+		// messages already off the queue are still processed and deleted.
+		drainCtx := context.WithoutCancel(ctx)
+		var batch sync.WaitGroup
+		for _, msg := range messages {
+			batch.Add(1)
+			go func(msg backend.Message) {
+				defer batch.Done()
+				h.processStatusMessage(drainCtx, msg)
+			}(msg)
+		}
+		batch.Wait()
+	}
+}
+
+// StartDLQListener drains RequestDLQURL, the queue a redrive policy on
+// StatusQueueURL moves messages to once they exceed MaxReceiveCount deliveries,
+// and records each one as a permanent per-image failure. Like
+// StartStatusListener, it drains any in-flight batch before honoring
+// ctx cancellation.
+func (h *Handler) StartDLQListener(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if h.config.RequestDLQURL == "" {
+		return
+	}
+	log.Println("Starting SQS DLQ listener...")
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("DLQ listener shutting down...")
+			return
+		default:
+		}
+
+		messages, err := h.queue.ReceiveMessages(ctx, h.config.RequestDLQURL)
+		if err != nil {
+			log.Printf("Error receiving DLQ messages: %v", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		drainCtx := context.WithoutCancel(ctx)
+		var batch sync.WaitGroup
 		for _, msg := range messages {
-			go h.processStatusMessage(msg)
+			batch.Add(1)
+			go func(msg backend.Message) {
+				defer batch.Done()
+				h.processDLQMessage(drainCtx, msg)
+			}(msg)
 		}
+		batch.Wait()
 	}
 }
 
-func (h *Handler) processStatusMessage(msg *sqs.Message) {
-	var statusMsg models.StatusMessage
-	if err := json.Unmarshal([]byte(*msg.Body), &statusMsg); err != nil {
+func (h *Handler) processDLQMessage(ctx context.Context, msg backend.Message) {
+	statusMsg, err := backend.Consume[models.StatusMessage](h.codec, msg)
+	if err != nil {
+		log.Printf("Error parsing DLQ message: %v", err)
+		h.queue.DeleteMessage(ctx, h.config.RequestDLQURL, msg.ReceiptHandle)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	job, err := h.jobStore.GetJob(ctx, statusMsg.JobID)
+	if err != nil {
+		log.Printf("DLQ: job not found: %s", statusMsg.JobID)
+		h.queue.DeleteMessage(ctx, h.config.RequestDLQURL, msg.ReceiptHandle)
+		return
+	}
+
+	s3Key := h.resolveFailedS3Key(job, statusMsg)
+	reason := statusMsg.Error
+	if reason == "" {
+		reason = fmt.Sprintf("exceeded max receive count (%d) without succeeding", h.config.MaxReceiveCount)
+	}
+
+	log.Printf("DLQ: permanently failing job %s, S3Key %s", statusMsg.JobID, s3Key)
+	h.recordPermanentFailure(job, s3Key, reason)
+	h.jobStore.UpdateJob(ctx, job)
+
+	h.queue.DeleteMessage(ctx, h.config.RequestDLQURL, msg.ReceiptHandle)
+}
+
+func (h *Handler) processStatusMessage(ctx context.Context, msg backend.Message) {
+	statusMsg, err := backend.Consume[models.StatusMessage](h.codec, msg)
+	if err != nil {
 		log.Printf("Error parsing status message: %v", err)
-		h.sqsSvc.DeleteMessage(h.config.StatusQueueURL, msg.ReceiptHandle)
+		h.queue.DeleteMessage(ctx, h.config.StatusQueueURL, msg.ReceiptHandle)
 		return
 	}
 
@@ -221,10 +336,10 @@ func (h *Handler) processStatusMessage(msg *sqs.Message) {
 	defer h.mu.Unlock()
 
 	// Get the job
-	job, err := h.dynamoSvc.GetJob(statusMsg.JobID)
+	job, err := h.jobStore.GetJob(ctx, statusMsg.JobID)
 	if err != nil {
 		log.Printf("Job not found: %s", statusMsg.JobID)
-		h.sqsSvc.DeleteMessage(h.config.StatusQueueURL, msg.ReceiptHandle)
+		h.queue.DeleteMessage(ctx, h.config.StatusQueueURL, msg.ReceiptHandle)
 		return
 	}
 
@@ -277,7 +392,7 @@ func (h *Handler) processStatusMessage(msg *sqs.Message) {
 
 		// Also merge output paths if any are returned by ML service (though copy happens below)
 		// Actually, we'll do the copy here for the individual image
-		outputPaths, err := h.copyImagesToOutput(statusMsg.JobID, statusMsg.Result)
+		outputPaths, err := h.copyImagesToOutput(ctx, statusMsg.JobID, statusMsg.Result)
 		if err != nil {
 			log.Printf("Error copying images: %v", err)
 		}
@@ -288,58 +403,139 @@ func (h *Handler) processStatusMessage(msg *sqs.Message) {
 			job.Result.OutputPaths[k] = v
 		}
 
-		// Check if job is fully complete
-		if len(job.Result.DetailedResults) >= len(job.S3Keys) {
-			completedAt := time.Now()
-			job.Status = "completed"
-			job.CompletedAt = &completedAt
-			// job.Result is already updated
+		job.Error = ""
+		h.finalizeJobProgress(job)
+		h.jobStore.UpdateJob(ctx, job)
+
+	} else if statusMsg.Status == "failed" {
+		// A failure is scoped to the single image the ML worker was processing for this
+		// sub-task, not the whole job - the SubmitJob flow fans one SQS message out per
+		// S3Key, so one bad image should never take down the rest of the batch.
+		receiveCount := backend.ApproximateReceiveCount(msg)
+		if !h.retryPolicy.Exhausted(receiveCount) {
+			// Transient failure: leave the message on the queue and back it off
+			// exponentially instead of retrying immediately.
+			backoff := h.retryPolicy.Backoff(receiveCount)
+			log.Printf("Transient failure for job %s (attempt %d/%d), backing off %s", statusMsg.JobID, receiveCount, h.retryPolicy.MaxAttempts, backoff)
+			h.queue.ChangeMessageVisibility(ctx, h.config.StatusQueueURL, msg.ReceiptHandle, int64(backoff.Seconds()))
+			return
+		}
+
+		// Retries exhausted. When a DLQ is configured, push the original message
+		// there with enough context to triage it without reparsing the body and
+		// let the dedicated DLQ listener (processDLQMessage) own marking the
+		// image permanently failed, so it's recorded exactly once instead of
+		// once here and again when the DLQ listener drains it. With no DLQ
+		// configured there's no listener to do that job, so record the failure
+		// here instead of dropping it; same if the DLQ send itself fails.
+		job.Attempts = receiveCount
+		job.FailureReason = statusMsg.Error
+
+		if h.config.RequestDLQURL != "" {
+			if err := backend.SendToDeadLetterQueue(ctx, h.queue, h.config.RequestDLQURL, msg, map[string]string{
+				"job_id":     statusMsg.JobID,
+				"last_error": statusMsg.Error,
+				"stack":      string(debug.Stack()),
+			}); err != nil {
+				log.Printf("Failed to send job %s to dead-letter queue: %v", statusMsg.JobID, err)
+				s3Key := h.resolveFailedS3Key(job, statusMsg)
+				h.recordPermanentFailure(job, s3Key, statusMsg.Error)
+			}
+			h.jobStore.UpdateJob(ctx, job)
 		} else {
-			job.Status = "processing"
+			s3Key := h.resolveFailedS3Key(job, statusMsg)
+			h.recordPermanentFailure(job, s3Key, statusMsg.Error)
+			h.jobStore.UpdateJob(ctx, job)
 		}
+	}
 
-		job.UpdatedAt = time.Now()
+	// Delete message from queue
+	h.queue.DeleteMessage(ctx, h.config.StatusQueueURL, msg.ReceiptHandle)
+}
+
+// recordPermanentFailure marks s3Key as permanently failed on job.Result, or
+// fails the whole job if the failure couldn't be mapped back to a specific image.
+func (h *Handler) recordPermanentFailure(job *models.Job, s3Key, reason string) {
+	if job.Result == nil {
+		job.Result = &models.ClassificationResult{
+			JobID:           job.JobID,
+			JobType:         job.JobType,
+			GroupedByLabel:  make(map[string][]string),
+			DetailedResults: make([]models.ImageResult, 0),
+			OutputPaths:     make(map[string]string),
+		}
+	}
+
+	if s3Key == "" {
+		// Couldn't map the failure back to a specific image - fall back to failing
+		// the whole job rather than silently dropping the status update.
+		job.Status = "failed"
+		job.Error = reason
+	} else {
+		appendOrUpdateFailure(job.Result, s3Key, reason)
 		job.Error = ""
+		h.finalizeJobProgress(job)
+	}
 
-		h.dynamoSvc.UpdateJob(job)
+	job.UpdatedAt = time.Now()
+}
 
-	} else if statusMsg.Status == "failed" {
-		// For individual failures, we might fail the whole job or just mark that image as failed.
-		// The original logic was "fail job". Let's stick to that for now, OR we could mark partial failure.
-		// Given the wrapper splits requests, a failure might be specific to one image.
-		// Ideally, we'd mark just that image as failed in results, but keeping it simple:
-		// Set job to failed if it's critical. However, ML service usually returns "success: false" in result for handled errors.
-		// If status is "failed", it's a system error.
+// resolveFailedS3Key maps a failed StatusMessage back to the S3Key of the image that
+// failed, preferring the key the worker echoed directly and falling back to the
+// sub_task_id minted in SubmitJob (format "<jobID>:<s3Key>").
+func (h *Handler) resolveFailedS3Key(job *models.Job, statusMsg models.StatusMessage) string {
+	if statusMsg.S3Key != "" {
+		return statusMsg.S3Key
+	}
 
-		job.Status = "failed"
-		job.Error = statusMsg.Error
-		job.UpdatedAt = time.Now()
-		job.RetryCount++ // This retry count is for the job, but we are processing sub-tasks.
-		// This might be tricky. If one image fails retrying the whole job is bad.
-		// But let's keep the existing retry logic for now as requested.
-
-		// Retry logic - Re-queue ONLY this specific sub-task?
-		// The original code re-queued the whole job. We should probably re-queue just this message.
-		// But statusMsg doesn't easily map back to the original SQS message payload unless we store it.
-		// For simplicity, we acknowledge the failure and let the user decide, OR we could try to re-queue.
-
-		// Let's just log and update for now.
-		// If we want to be robust, we should fix the Retry Logic to be per-image too, but that requires more changes.
-		// I will assume for this task, updating the status to failed (or partial failure) is enough.
-
-		if job.RetryCount < 2 {
-			// ... (Keep existing retry logic but applied to the whole job? No, that would re-process everything)
-			// Simpler: Just mark failed for now.
+	prefix := job.JobID + ":"
+	if strings.HasPrefix(statusMsg.SubTaskID, prefix) {
+		return strings.TrimPrefix(statusMsg.SubTaskID, prefix)
+	}
+
+	return ""
+}
+
+// appendOrUpdateFailure records a per-image failure on the job result, bumping the
+// retry count if this S3Key has already failed a previous attempt.
+func appendOrUpdateFailure(result *models.ClassificationResult, s3Key, reason string) {
+	for i := range result.FailedImages {
+		if result.FailedImages[i].S3Key == s3Key {
+			result.FailedImages[i].Reason = reason
+			result.FailedImages[i].RetryCount++
+			result.FailedImages[i].LastAttempt = time.Now()
+			return
 		}
+	}
 
-		h.dynamoSvc.UpdateJob(job)
+	result.FailedImages = append(result.FailedImages, models.ImageFailure{
+		S3Key:       s3Key,
+		Reason:      reason,
+		RetryCount:  0,
+		LastAttempt: time.Now(),
+	})
+}
+
+// finalizeJobProgress decides the job's terminal state once every image has either
+// produced a DetailedResult or landed in FailedImages: "completed" if none failed,
+// "completed_with_errors" if some did, or leaves the job "processing" otherwise.
+func (h *Handler) finalizeJobProgress(job *models.Job) {
+	processed := len(job.Result.DetailedResults) + len(job.Result.FailedImages)
+	if processed < len(job.S3Keys) {
+		job.Status = "processing"
+		return
 	}
 
-	// Delete message from queue
-	h.sqsSvc.DeleteMessage(h.config.StatusQueueURL, msg.ReceiptHandle)
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	if len(job.Result.FailedImages) > 0 {
+		job.Status = "completed_with_errors"
+	} else {
+		job.Status = "completed"
+	}
 }
 
-func (h *Handler) copyImagesToOutput(jobID string, result *models.ClassificationResult) (map[string]string, error) {
+func (h *Handler) copyImagesToOutput(ctx context.Context, jobID string, result *models.ClassificationResult) (map[string]string, error) {
 	outputPaths := make(map[string]string)
 
 	for label, filenames := range result.GroupedByLabel {
@@ -361,19 +557,90 @@ func (h *Handler) copyImagesToOutput(jobID string, result *models.Classification
 			outputKey := fmt.Sprintf("%s/%s/%s", jobID, label, filename)
 
 			// Copy from input to output bucket
-			err := h.s3Svc.CopyObject(h.config.InputBucket, originalKey, h.config.OutputBucket, outputKey)
+			err := h.s3Svc.CopyObject(ctx, h.config.InputBucketURL, originalKey, h.config.OutputBucketURL, outputKey)
 			if err != nil {
 				log.Printf("Failed to copy %s: %v", originalKey, err)
 				continue
 			}
 
-			outputPaths[originalKey] = fmt.Sprintf("s3://%s/%s", h.config.OutputBucket, outputKey)
+			outputPaths[originalKey] = fmt.Sprintf("%s/%s", h.config.OutputBucketURL, outputKey)
 		}
 	}
 
 	return outputPaths, nil
 }
 
+// RetryFailedImages re-enqueues only the S3Keys currently recorded in
+// job.Result.FailedImages, clearing them from the result so the status listener
+// can re-accumulate fresh outcomes as the worker reprocesses them.
+func (h *Handler) RetryFailedImages(c *gin.Context) {
+	jobID := c.Param("jobId")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := c.Request.Context()
+
+	job, err := h.jobStore.GetJob(ctx, jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	if job.Result == nil || len(job.Result.FailedImages) == 0 {
+		c.JSON(http.StatusOK, models.RetryFailedResponse{
+			JobID:    jobID,
+			Requeued: []string{},
+			Message:  "No failed images to retry",
+		})
+		return
+	}
+
+	requeued := make([]string, 0, len(job.Result.FailedImages))
+	for _, failure := range job.Result.FailedImages {
+		sqsMsg := models.SQSMessage{
+			JobID:               jobID,
+			JobType:             job.JobType,
+			S3Bucket:            job.InputBucket,
+			S3Keys:              []string{failure.S3Key},
+			CustomLabels:        job.CustomLabels,
+			TopK:                job.TopK,
+			ConfidenceThreshold: job.ConfidenceThreshold,
+			RetryCount:          failure.RetryCount + 1,
+			SubTaskID:           fmt.Sprintf("%s:%s", jobID, failure.S3Key),
+		}
+
+		if err := backend.Publish(ctx, h.queue, h.codec, h.config.RequestQueueURL, sqsMsg); err != nil {
+			log.Printf("Failed to requeue S3Key %s for JobID %s: %v", failure.S3Key, jobID, err)
+			continue
+		}
+		requeued = append(requeued, failure.S3Key)
+	}
+
+	// Drop the requeued failures from FailedImages so finalizeJobProgress treats
+	// them as outstanding again; failures that could not be requeued stay.
+	remaining := job.Result.FailedImages[:0]
+	requeuedSet := make(map[string]bool, len(requeued))
+	for _, key := range requeued {
+		requeuedSet[key] = true
+	}
+	for _, failure := range job.Result.FailedImages {
+		if !requeuedSet[failure.S3Key] {
+			remaining = append(remaining, failure)
+		}
+	}
+	job.Result.FailedImages = remaining
+	job.Status = "processing"
+	job.UpdatedAt = time.Now()
+	h.jobStore.UpdateJob(ctx, job)
+
+	c.JSON(http.StatusOK, models.RetryFailedResponse{
+		JobID:    jobID,
+		Requeued: requeued,
+		Message:  fmt.Sprintf("Requeued %d failed image(s)", len(requeued)),
+	})
+}
+
 // GetUploadURL generates a presigned URL for uploading an image to S3
 func (h *Handler) GetUploadURL(c *gin.Context) {
 	var req models.UploadURLRequest
@@ -398,7 +665,7 @@ func (h *Handler) GetUploadURL(c *gin.Context) {
 
 	// Generate presigned URL (valid for 1 hour)
 	expiration := 1 * time.Hour
-	presignedURL, err := h.s3Svc.GetPresignedUploadURL(h.config.InputBucket, s3Key, req.ContentType, expiration)
+	presignedURL, err := h.s3Svc.GetPresignedUploadURL(c.Request.Context(), h.config.InputBucketURL, s3Key, req.ContentType, expiration)
 	if err != nil {
 		log.Printf("Error generating presigned URL: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate upload URL"})
@@ -413,12 +680,161 @@ func (h *Handler) GetUploadURL(c *gin.Context) {
 	})
 }
 
+// ArchiveJobsNow kicks off an on-demand DynamoDB PITR export of the Jobs table
+func (h *Handler) ArchiveJobsNow(c *gin.Context) {
+	exportArn, err := h.archiverSvc.StartExport(c.Request.Context())
+	if err != nil {
+		log.Printf("Error starting archive export: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start archive export"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.ArchiveExportResponse{
+		ExportArn: exportArn,
+		Status:    "IN_PROGRESS",
+	})
+}
+
+// GetArchiveStatus reports the status of a previously started archive export
+func (h *Handler) GetArchiveStatus(c *gin.Context) {
+	exportArn := c.Param("exportArn")
+
+	desc, err := h.archiverSvc.DescribeExport(c.Request.Context(), exportArn)
+	if err != nil {
+		log.Printf("Error describing archive export: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+
+	response := models.ArchiveExportResponse{
+		ExportArn: exportArn,
+		Status:    "UNKNOWN",
+	}
+	if desc.ExportStatus != "" {
+		response.Status = string(desc.ExportStatus)
+	}
+	if desc.FailureMessage != nil {
+		response.FailureMessage = *desc.FailureMessage
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// InitiateMultipartUpload starts an S3 multipart upload for a large image archive and
+// returns the UploadID the client needs for each part URL and the completion call.
+func (h *Handler) InitiateMultipartUpload(c *gin.Context) {
+	var req models.InitiateMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timestamp := time.Now().Format("20060102-150405")
+	uniqueID := uuid.New().String()[:8]
+	ext := filepath.Ext(req.Filename)
+	baseName := strings.TrimSuffix(req.Filename, ext)
+	if baseName == "" {
+		baseName = "upload"
+	}
+	baseName = strings.ReplaceAll(baseName, " ", "_")
+	baseName = strings.ReplaceAll(baseName, "/", "_")
+
+	s3Key := fmt.Sprintf("uploads/%s_%s_%s%s", baseName, timestamp, uniqueID, ext)
+
+	uploadID, err := h.s3Svc.InitiateMultipartUpload(c.Request.Context(), h.config.InputBucketURL, s3Key, req.ContentType)
+	if err != nil {
+		log.Printf("Error initiating multipart upload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initiate multipart upload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.InitiateMultipartUploadResponse{
+		UploadID: uploadID,
+		S3Key:    s3Key,
+	})
+}
+
+// GetUploadPartURL generates a presigned PUT URL for a single part of a multipart upload
+func (h *Handler) GetUploadPartURL(c *gin.Context) {
+	uploadID := c.Param("uploadId")
+	var partNumber int64
+	if _, err := fmt.Sscanf(c.Param("partNumber"), "%d", &partNumber); err != nil || partNumber < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part number"})
+		return
+	}
+
+	var req models.UploadPartURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expiration := 1 * time.Hour
+	presignedURL, err := h.s3Svc.GetPresignedUploadPartURL(c.Request.Context(), h.config.InputBucketURL, req.S3Key, uploadID, partNumber, expiration)
+	if err != nil {
+		log.Printf("Error generating part upload URL: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate part upload URL"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UploadPartURLResponse{
+		UploadURL:  presignedURL,
+		PartNumber: partNumber,
+		ExpiresAt:  time.Now().Add(expiration),
+	})
+}
+
+// CompleteMultipartUpload finalizes a multipart upload once every part has uploaded
+func (h *Handler) CompleteMultipartUpload(c *gin.Context) {
+	uploadID := c.Param("uploadId")
+
+	var req models.CompleteMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	parts := make([]backend.CompletedPart, 0, len(req.Parts))
+	for _, p := range req.Parts {
+		parts = append(parts, backend.CompletedPart{PartNumber: p.PartNumber, ETag: p.ETag})
+	}
+
+	if err := h.s3Svc.CompletePresignedMultipartUpload(c.Request.Context(), h.config.InputBucketURL, req.S3Key, uploadID, parts); err != nil {
+		log.Printf("Error completing multipart upload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete multipart upload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CompleteMultipartUploadResponse{
+		S3Key:   req.S3Key,
+		Success: true,
+	})
+}
+
+// AbortMultipartUpload cancels an in-progress multipart upload
+func (h *Handler) AbortMultipartUpload(c *gin.Context) {
+	uploadID := c.Param("uploadId")
+	s3Key := c.Query("s3_key")
+	if s3Key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "s3_key query parameter is required"})
+		return
+	}
+
+	if err := h.s3Svc.AbortMultipartUpload(c.Request.Context(), h.config.InputBucketURL, s3Key, uploadID); err != nil {
+		log.Printf("Error aborting multipart upload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to abort multipart upload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
 // ListImages returns a list of all images in the input bucket
 func (h *Handler) ListImages(c *gin.Context) {
 	// Optional prefix filter from query parameter
 	prefix := c.Query("prefix")
 
-	objects, err := h.s3Svc.ListObjects(h.config.InputBucket, prefix)
+	objects, err := h.s3Svc.ListObjects(c.Request.Context(), h.config.InputBucketURL, prefix)
 	if err != nil {
 		log.Printf("Error listing objects: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list images"})
@@ -437,19 +853,14 @@ func (h *Handler) ListImages(c *gin.Context) {
 
 	var images []models.ImageInfo
 	for _, obj := range objects {
-		if obj.Key == nil {
-			continue
-		}
-
-		key := *obj.Key
-		ext := strings.ToLower(filepath.Ext(key))
+		ext := strings.ToLower(filepath.Ext(obj.Key))
 
 		// Only include image files
 		if imageExtensions[ext] {
 			images = append(images, models.ImageInfo{
-				Key:          key,
-				Size:         aws.Int64Value(obj.Size),
-				LastModified: aws.TimeValue(obj.LastModified),
+				Key:          obj.Key,
+				Size:         obj.Size,
+				LastModified: obj.LastModified,
 			})
 		}
 	}
@@ -481,7 +892,7 @@ func (h *Handler) DeleteImage(c *gin.Context) {
 		return
 	}
 
-	err = h.s3Svc.DeleteObject(h.config.InputBucket, s3Key)
+	err = h.s3Svc.DeleteObject(c.Request.Context(), h.config.InputBucketURL, s3Key)
 	if err != nil {
 		log.Printf("Error deleting object: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete image"})
@@ -494,11 +905,14 @@ func (h *Handler) DeleteImage(c *gin.Context) {
 	})
 }
 
-// ListJobs returns a list of all jobs with optional filtering
+// ListJobs returns a page of jobs with optional status filtering, ordered by
+// created_at descending. Pass the next_page_token from a response back as the
+// page_token query param to fetch the following page.
 func (h *Handler) ListJobs(c *gin.Context) {
 	// Get query parameters
 	limitStr := c.DefaultQuery("limit", "100")
 	statusFilter := c.Query("status")
+	pageToken := c.Query("page_token")
 
 	// Parse limit
 	var limit int
@@ -511,7 +925,7 @@ func (h *Handler) ListJobs(c *gin.Context) {
 	}
 
 	// Get jobs from DynamoDB
-	jobs, err := h.dynamoSvc.ListJobs(limit, statusFilter)
+	jobs, nextPageToken, err := h.jobStore.ListJobs(c.Request.Context(), limit, statusFilter, pageToken)
 	if err != nil {
 		log.Printf("Error listing jobs: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
@@ -532,7 +946,7 @@ func (h *Handler) ListJobs(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, models.ListJobsResponse{
-		Jobs:  summaries,
-		Total: len(summaries),
+		Jobs:          summaries,
+		NextPageToken: nextPageToken,
 	})
 }
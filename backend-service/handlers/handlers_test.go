@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"testing"
+
+	"distributed-classifier/backend/models"
+)
+
+func TestAppendOrUpdateFailureAddsNewEntry(t *testing.T) {
+	result := &models.ClassificationResult{}
+
+	appendOrUpdateFailure(result, "input/a.jpg", "model timeout")
+
+	if len(result.FailedImages) != 1 {
+		t.Fatalf("expected 1 failed image, got %d", len(result.FailedImages))
+	}
+	got := result.FailedImages[0]
+	if got.S3Key != "input/a.jpg" || got.Reason != "model timeout" || got.RetryCount != 0 {
+		t.Fatalf("unexpected failure entry: %+v", got)
+	}
+}
+
+func TestAppendOrUpdateFailureBumpsRetryCountOnRepeat(t *testing.T) {
+	result := &models.ClassificationResult{}
+	appendOrUpdateFailure(result, "input/a.jpg", "model timeout")
+
+	appendOrUpdateFailure(result, "input/a.jpg", "still failing")
+
+	if len(result.FailedImages) != 1 {
+		t.Fatalf("expected the repeat failure to update the existing entry, got %d entries", len(result.FailedImages))
+	}
+	got := result.FailedImages[0]
+	if got.Reason != "still failing" || got.RetryCount != 1 {
+		t.Fatalf("expected reason updated and retry count bumped to 1, got %+v", got)
+	}
+}
+
+func TestFinalizeJobProgressStillProcessingWhenImagesOutstanding(t *testing.T) {
+	job := &models.Job{
+		S3Keys: []string{"a.jpg", "b.jpg"},
+		Result: &models.ClassificationResult{
+			DetailedResults: []models.ImageResult{{S3Key: "a.jpg"}},
+		},
+	}
+
+	(&Handler{}).finalizeJobProgress(job)
+
+	if job.Status != "processing" {
+		t.Fatalf("expected status processing while images remain outstanding, got %q", job.Status)
+	}
+	if job.CompletedAt != nil {
+		t.Fatal("expected CompletedAt to stay unset while still processing")
+	}
+}
+
+func TestFinalizeJobProgressCompletedWithNoFailures(t *testing.T) {
+	job := &models.Job{
+		S3Keys: []string{"a.jpg"},
+		Result: &models.ClassificationResult{
+			DetailedResults: []models.ImageResult{{S3Key: "a.jpg"}},
+		},
+	}
+
+	(&Handler{}).finalizeJobProgress(job)
+
+	if job.Status != "completed" {
+		t.Fatalf("expected status completed, got %q", job.Status)
+	}
+	if job.CompletedAt == nil {
+		t.Fatal("expected CompletedAt to be set once the job finalizes")
+	}
+}
+
+func TestFinalizeJobProgressCompletedWithErrorsWhenSomeImagesFailed(t *testing.T) {
+	job := &models.Job{
+		S3Keys: []string{"a.jpg", "b.jpg"},
+		Result: &models.ClassificationResult{
+			DetailedResults: []models.ImageResult{{S3Key: "a.jpg"}},
+			FailedImages:    []models.ImageFailure{{S3Key: "b.jpg"}},
+		},
+	}
+
+	(&Handler{}).finalizeJobProgress(job)
+
+	if job.Status != "completed_with_errors" {
+		t.Fatalf("expected status completed_with_errors, got %q", job.Status)
+	}
+}
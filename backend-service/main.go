@@ -4,11 +4,18 @@ import (
 	"context"
 	"distributed-classifier/backend/config"
 	"distributed-classifier/backend/handlers"
+	"distributed-classifier/backend/models"
 	"distributed-classifier/backend/services"
+	"distributed-classifier/backend/services/archiver"
+	"distributed-classifier/backend/services/aws"
+	"distributed-classifier/backend/services/backend"
+	"distributed-classifier/backend/services/natspg"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -19,16 +26,56 @@ func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
-	// Initialize AWS services
-	dynamoSvc := services.NewDynamoService(cfg)
-	s3Svc := services.NewS3Service(cfg)
-	sqsSvc := services.NewSQSService(cfg)
+	// Initialize the JobStore/ObjectStore/MessageQueue backend selected by
+	// cfg.Backend ("aws" or "nats_pg") and inject the interfaces, not the
+	// concrete implementations, into the handler.
+	jobStore := newJobStore(cfg)
+	objectStore := newObjectStore(cfg)
+	queue := newMessageQueue(cfg)
+	codec := backend.NewCodec(cfg.MessageCodec)
+	if avroCodec, ok := codec.(*backend.AvroCodec); ok {
+		// Every message type that goes over the request/status queues needs a
+		// schema registered before the avro codec can marshal or unmarshal it.
+		if err := avroCodec.RegisterType(models.SQSMessage{}); err != nil {
+			log.Fatalf("Failed to register avro schema for SQSMessage: %v", err)
+		}
+		if err := avroCodec.RegisterType(models.StatusMessage{}); err != nil {
+			log.Fatalf("Failed to register avro schema for StatusMessage: %v", err)
+		}
+	}
+	// Archival is a DynamoDB PITR export and only makes sense for Backend ==
+	// "aws" - Postgres has no equivalent here, so archiverSvc stays nil and the
+	// nightly loop/admin routes below stay off under "nats_pg" instead of making
+	// a real DynamoDB call against whatever DYNAMODB_TABLE happens to be set.
+	var archiverSvc *archiver.Archiver
+	if cfg.Backend == "aws" {
+		archiverSvc = archiver.NewArchiver(cfg)
+	}
 
 	// Create handler with services
-	handler := handlers.NewHandler(dynamoSvc, s3Svc, sqsSvc, cfg)
+	handler := handlers.NewHandler(jobStore, objectStore, queue, codec, archiverSvc, cfg)
 
-	// Start SQS status listener in background
-	go handler.StartStatusListener()
+	// rootCtx is cancelled on SIGINT/SIGTERM and shared by the HTTP server's
+	// request contexts and the background listeners, so everything that needs
+	// to stop on shutdown hears about it at the same time.
+	rootCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Start SQS status and DLQ listeners in the background, tracked by a
+	// WaitGroup so main can drain them before the process exits.
+	var listeners sync.WaitGroup
+	listeners.Add(2)
+	go handler.StartStatusListener(rootCtx, &listeners)
+	go handler.StartDLQListener(rootCtx, &listeners)
+
+	// Start multipart upload janitor to clean up abandoned uploads
+	janitor := backend.NewMultipartJanitor(objectStore, cfg.InputBucketURL, cfg.MultipartUploadTTL)
+	go janitor.Run(cfg.MultipartJanitorInterval)
+
+	// Start nightly DynamoDB archive export, if enabled
+	if archiverSvc != nil && cfg.ArchiveEnabled {
+		go archiverSvc.RunNightly(rootCtx, cfg.ArchiveInterval)
+	}
 
 	// Setup Gin router
 	router := gin.New()
@@ -40,11 +87,24 @@ func main() {
 	router.POST("/submit", handler.SubmitJob)
 	router.GET("/status/:jobId", handler.GetJobStatus)
 	router.GET("/result/:jobId", handler.GetJobResult)
+	router.GET("/jobs", handler.ListJobs)
+	router.POST("/jobs/:jobId/retry-failed", handler.RetryFailedImages)
+	router.POST("/uploads/multipart", handler.InitiateMultipartUpload)
+	router.POST("/uploads/multipart/:uploadId/parts/:partNumber/url", handler.GetUploadPartURL)
+	router.POST("/uploads/multipart/:uploadId/complete", handler.CompleteMultipartUpload)
+	router.DELETE("/uploads/multipart/:uploadId", handler.AbortMultipartUpload)
+	if archiverSvc != nil {
+		router.POST("/admin/jobs/archive", handler.ArchiveJobsNow)
+		router.GET("/admin/jobs/archive/:exportArn", handler.GetArchiveStatus)
+	}
 
 	// Server configuration
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
 		Handler: router,
+		BaseContext: func(net.Listener) context.Context {
+			return rootCtx
+		},
 	}
 
 	// Start server in goroutine
@@ -60,13 +120,79 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	cancel()
 
 	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	// Drain the status/DLQ listeners so an in-flight message isn't lost mid-handling.
+	listeners.Wait()
+
 	log.Println("Server exited")
 }
+
+// newJobStore picks the JobStore implementation for cfg.Backend: DynamoDB for
+// "aws" (the default), or Postgres for "nats_pg".
+func newJobStore(cfg *config.Config) backend.JobStore {
+	if cfg.Backend == "nats_pg" {
+		store, err := natspg.NewPostgresJobStore(cfg.DatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to initialize Postgres job store: %v", err)
+		}
+		return store
+	}
+
+	return aws.NewDynamoService(cfg)
+}
+
+// newMessageQueue picks the MessageQueue implementation for cfg.Backend: SQS
+// for "aws" (the default), or NATS JetStream for "nats_pg".
+func newMessageQueue(cfg *config.Config) backend.MessageQueue {
+	if cfg.Backend == "nats_pg" {
+		queue, err := natspg.NewNATSMessageQueue(cfg.NATSURL, cfg.NATSStreamName)
+		if err != nil {
+			log.Fatalf("Failed to initialize NATS message queue: %v", err)
+		}
+		return queue
+	}
+
+	return aws.NewSQSService(cfg)
+}
+
+// newObjectStore picks the ObjectStore implementation(s) backing
+// InputBucketURL and OutputBucketURL. Each bucket is served by the backend
+// matching its own scheme - "s3://" keeps using the hand-rolled AWS SDK client
+// (it also backs presigned multipart uploads, which gocloud.dev/blob doesn't
+// support), every other scheme goes through the gocloud.dev/blob driver
+// selected by that scheme - so input and output buckets can live on different
+// clouds. This is independent of cfg.Backend: even under BACKEND=nats_pg,
+// object storage still goes through S3 or another gocloud.dev/blob driver,
+// since NATS/Postgres only replace the queue and job-metadata store.
+func newObjectStore(cfg *config.Config) backend.ObjectStore {
+	needsS3 := config.IsS3BucketURL(cfg.InputBucketURL) || config.IsS3BucketURL(cfg.OutputBucketURL)
+	needsFallback := !config.IsS3BucketURL(cfg.InputBucketURL) || !config.IsS3BucketURL(cfg.OutputBucketURL)
+
+	var s3Svc backend.ObjectStore
+	if needsS3 {
+		s3Svc = aws.NewS3Service(cfg)
+	}
+
+	var fallback backend.ObjectStore
+	if needsFallback {
+		log.Printf("Using gocloud.dev/blob object store for non-S3 bucket(s) (input=%s, output=%s)", cfg.InputBucketURL, cfg.OutputBucketURL)
+		fallback = services.NewGoCloudBlobService()
+	}
+
+	switch {
+	case needsS3 && needsFallback:
+		return services.NewRoutingObjectStore(s3Svc, fallback)
+	case needsS3:
+		return s3Svc
+	default:
+		return fallback
+	}
+}
@@ -5,6 +5,10 @@ import "time"
 // Job represents a classification job
 type Job struct {
     JobID            string                 `json:"job_id" dynamodbav:"job_id"`
+    // EntityType is always "job" - it exists solely as the hash key of the
+    // entity_type-created_at-index GSI so ListJobs can page through every job
+    // in created_at order without filtering by status.
+    EntityType       string                 `json:"-" dynamodbav:"entity_type"`
     Status           string                 `json:"status" dynamodbav:"status"`
     JobType          string                 `json:"job_type" dynamodbav:"job_type"`
     InputBucket      string                 `json:"input_bucket" dynamodbav:"input_bucket"`
@@ -18,6 +22,11 @@ type Job struct {
     Result           *ClassificationResult  `json:"result,omitempty" dynamodbav:"result,omitempty"`
     Error            string                 `json:"error,omitempty" dynamodbav:"error,omitempty"`
     RetryCount       int                    `json:"retry_count" dynamodbav:"retry_count"`
+    // FailureReason and Attempts are set when a status message is moved to the
+    // dead-letter queue after exhausting its RetryPolicy, so operators can see
+    // why via GET /status/:jobId without digging through SQS.
+    FailureReason    string                 `json:"failure_reason,omitempty" dynamodbav:"failure_reason,omitempty"`
+    Attempts         int                    `json:"attempts,omitempty" dynamodbav:"attempts,omitempty"`
 }
 
 // SubmitJobRequest represents the API request to submit a job
@@ -46,6 +55,87 @@ type SQSMessage struct {
     TopK             int      `json:"top_k"`
     ConfidenceThreshold float64 `json:"confidence_threshold"`
     RetryCount       int      `json:"retry_count"`
+    // SubTaskID identifies this single-image sub-task so a failure StatusMessage
+    // can be mapped back to the S3Key even if the worker doesn't echo it directly.
+    SubTaskID        string   `json:"sub_task_id"`
+}
+
+// RetryFailedResponse represents the API response for retrying a job's failed images
+type RetryFailedResponse struct {
+    JobID     string   `json:"job_id"`
+    Requeued  []string `json:"requeued"`
+    Message   string   `json:"message"`
+}
+
+// JobSummary is the lightweight projection of a Job returned by ListJobs -
+// it omits Result/Error to keep paginated listing responses small.
+type JobSummary struct {
+    JobID       string     `json:"job_id"`
+    Status      string     `json:"status"`
+    JobType     string     `json:"job_type"`
+    CreatedAt   time.Time  `json:"created_at"`
+    CompletedAt *time.Time `json:"completed_at,omitempty"`
+    NumImages   int        `json:"num_images"`
+}
+
+// ListJobsResponse represents the API response for a paginated job listing.
+// NextPageToken is omitted once there are no further pages. There's
+// intentionally no Total field: ListJobs is keyset-paginated, so the only
+// cheap count available is len(Jobs) (the current page size), which would be
+// redundant and misleading as a "total".
+type ListJobsResponse struct {
+    Jobs          []JobSummary `json:"jobs"`
+    NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+// ArchiveExportResponse represents the API response for a DynamoDB archive export
+type ArchiveExportResponse struct {
+    ExportArn      string `json:"export_arn"`
+    Status         string `json:"status"`
+    FailureMessage string `json:"failure_message,omitempty"`
+}
+
+// InitiateMultipartUploadRequest represents the API request to start a multipart upload
+type InitiateMultipartUploadRequest struct {
+    Filename    string `json:"filename" binding:"required"`
+    ContentType string `json:"content_type" binding:"required"`
+}
+
+// InitiateMultipartUploadResponse represents the API response for starting a multipart upload
+type InitiateMultipartUploadResponse struct {
+    UploadID string `json:"upload_id"`
+    S3Key    string `json:"s3_key"`
+}
+
+// UploadPartURLRequest represents the API request for a single presigned part URL
+type UploadPartURLRequest struct {
+    S3Key string `json:"s3_key" binding:"required"`
+}
+
+// UploadPartURLResponse represents the API response for a single presigned part URL
+type UploadPartURLResponse struct {
+    UploadURL  string `json:"upload_url"`
+    PartNumber int64  `json:"part_number"`
+    ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CompletedUploadPart is the ETag/PartNumber pair the client reports back once a part
+// finishes uploading to its presigned URL
+type CompletedUploadPart struct {
+    PartNumber int64  `json:"part_number" binding:"required"`
+    ETag       string `json:"etag" binding:"required"`
+}
+
+// CompleteMultipartUploadRequest represents the API request to finalize a multipart upload
+type CompleteMultipartUploadRequest struct {
+    S3Key string                `json:"s3_key" binding:"required"`
+    Parts []CompletedUploadPart `json:"parts" binding:"required,min=1"`
+}
+
+// CompleteMultipartUploadResponse represents the API response once a multipart upload is finalized
+type CompleteMultipartUploadResponse struct {
+    S3Key   string `json:"s3_key"`
+    Success bool   `json:"success"`
 }
 
 // ClassificationResult represents the ML service response
@@ -58,10 +148,19 @@ type ClassificationResult struct {
     ProcessingTime  float64                  `json:"processing_time_ms" dynamodbav:"processing_time_ms"`
     GroupedByLabel  map[string][]string      `json:"grouped_by_label" dynamodbav:"grouped_by_label"`
     DetailedResults []ImageResult            `json:"detailed_results" dynamodbav:"detailed_results"`
+    FailedImages    []ImageFailure           `json:"failed_images,omitempty" dynamodbav:"failed_images,omitempty"`
     Summary         ClassificationSummary    `json:"summary" dynamodbav:"summary"`
     OutputPaths     map[string]string        `json:"output_paths" dynamodbav:"output_paths"`
 }
 
+// ImageFailure represents a single image that could not be classified
+type ImageFailure struct {
+    S3Key       string    `json:"s3_key" dynamodbav:"s3_key"`
+    Reason      string    `json:"reason" dynamodbav:"reason"`
+    RetryCount  int       `json:"retry_count" dynamodbav:"retry_count"`
+    LastAttempt time.Time `json:"last_attempt" dynamodbav:"last_attempt"`
+}
+
 // ImageResult represents individual image classification
 type ImageResult struct {
     Filename        string       `json:"filename" dynamodbav:"filename"`
@@ -88,8 +187,10 @@ type ClassificationSummary struct {
 
 // StatusMessage represents the status update from ML service
 type StatusMessage struct {
-    JobID   string                `json:"job_id"`
-    Status  string                `json:"status"`
-    Result  *ClassificationResult `json:"result,omitempty"`
-    Error   string                `json:"error,omitempty"`
+    JobID     string                `json:"job_id"`
+    Status    string                `json:"status"`
+    Result    *ClassificationResult `json:"result,omitempty"`
+    Error     string                `json:"error,omitempty"`
+    S3Key     string                `json:"s3_key,omitempty"`      // echoed back by the ML worker for per-image failures
+    SubTaskID string                `json:"sub_task_id,omitempty"` // falls back to this when S3Key isn't echoed
 }
\ No newline at end of file
@@ -0,0 +1,254 @@
+// Package archiver exports the Jobs DynamoDB table to S3 via DynamoDB's
+// point-in-time-recovery (PITR) export feature, then prunes old terminal jobs
+// from the hot table once the export lands. Archived jobs stay queryable
+// through Athena/Glue over the S3 prefix instead of the live table.
+//
+// Requirements: the Jobs table must have PITR enabled, and the runtime IAM role
+// needs dynamodb:ExportTableToPointInTime, dynamodb:DescribeExport, and
+// s3:PutObject on the archive prefix.
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"distributed-classifier/backend/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// terminalStatuses are the job states eligible for archival and deletion from
+// the hot table once they're older than the retention window.
+var terminalStatuses = map[string]bool{
+	"completed":             true,
+	"completed_with_errors": true,
+	"failed":                true,
+}
+
+// DynamoDBAPI is the subset of *dynamodb.Client Archiver calls, so tests can
+// inject a fake instead of talking to real DynamoDB.
+type DynamoDBAPI interface {
+	ExportTableToPointInTime(ctx context.Context, params *dynamodb.ExportTableToPointInTimeInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error)
+	DescribeExport(ctx context.Context, params *dynamodb.DescribeExportInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeExportOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+}
+
+type Archiver struct {
+	client         DynamoDBAPI
+	tableName      string
+	tableArn       string
+	outputS3Bucket string
+	retention      time.Duration
+}
+
+// ClientOption customizes an Archiver at construction time, e.g. to inject a
+// DynamoDBAPI fake in place of a real client.
+type ClientOption func(*Archiver)
+
+// WithDynamoDBClient overrides the DynamoDBAPI NewArchiver would otherwise
+// build from cfg - used by tests to inject a dynamodbiface-style fake.
+func WithDynamoDBClient(client DynamoDBAPI) ClientOption {
+	return func(a *Archiver) {
+		a.client = client
+	}
+}
+
+func NewArchiver(cfg *config.Config, opts ...ClientOption) *Archiver {
+	a := &Archiver{
+		tableName:      cfg.TableName,
+		tableArn:       cfg.TableArn,
+		outputS3Bucket: cfg.OutputBucket,
+		retention:      cfg.ArchiveRetention,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if a.client == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+			awsconfig.WithRegion(cfg.AWSRegion),
+			awsconfig.WithRetryer(func() aws.Retryer {
+				return retry.NewAdaptiveMode()
+			}),
+		)
+		if err != nil {
+			log.Fatalf("Failed to load AWS config: %v", err)
+		}
+
+		a.client = dynamodb.NewFromConfig(awsCfg)
+	}
+
+	return a
+}
+
+// StartExport kicks off a DynamoDB PITR export of the Jobs table to
+// s3://<OutputBucket>/archives/jobs/dt=YYYY-MM-DD/ and returns its export ARN.
+func (a *Archiver) StartExport(ctx context.Context) (string, error) {
+	tableArn, err := a.resolveTableArn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolving table ARN: %w", err)
+	}
+
+	prefix := fmt.Sprintf("archives/jobs/dt=%s", time.Now().UTC().Format("2006-01-02"))
+
+	result, err := a.client.ExportTableToPointInTime(ctx, &dynamodb.ExportTableToPointInTimeInput{
+		TableArn:     aws.String(tableArn),
+		S3Bucket:     aws.String(a.outputS3Bucket),
+		S3Prefix:     aws.String(prefix),
+		ExportFormat: types.ExportFormatDynamodbJson,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(result.ExportDescription.ExportArn), nil
+}
+
+// DescribeExport polls the status of a previously started export.
+func (a *Archiver) DescribeExport(ctx context.Context, exportArn string) (*types.ExportDescription, error) {
+	result, err := a.client.DescribeExport(ctx, &dynamodb.DescribeExportInput{
+		ExportArn: aws.String(exportArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ExportDescription, nil
+}
+
+func (a *Archiver) resolveTableArn(ctx context.Context) (string, error) {
+	if a.tableArn != "" {
+		return a.tableArn, nil
+	}
+
+	result, err := a.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(a.tableName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	a.tableArn = aws.ToString(result.Table.TableArn)
+	return a.tableArn, nil
+}
+
+// RunNightly runs ArchiveOnce on the given interval until ctx is cancelled. It
+// blocks, so callers should invoke it via `go archiver.RunNightly(ctx, interval)`.
+func (a *Archiver) RunNightly(ctx context.Context, interval time.Duration) {
+	log.Printf("Starting nightly DynamoDB archive loop (interval=%s, retention=%s)", interval, a.retention)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Archiver: nightly loop shutting down...")
+			return
+		case <-time.After(interval):
+		}
+
+		if _, err := a.ArchiveOnce(ctx); err != nil {
+			log.Printf("Archiver: nightly export failed: %v", err)
+		}
+	}
+}
+
+// ArchiveOnce starts an export and blocks until it completes (or fails), then
+// prunes terminal jobs older than the retention window from the hot table.
+func (a *Archiver) ArchiveOnce(ctx context.Context) (string, error) {
+	exportArn, err := a.StartExport(ctx)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Archiver: started export %s", exportArn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return exportArn, ctx.Err()
+		case <-time.After(30 * time.Second):
+		}
+
+		desc, err := a.DescribeExport(ctx, exportArn)
+		if err != nil {
+			return exportArn, fmt.Errorf("describing export %s: %w", exportArn, err)
+		}
+
+		switch desc.ExportStatus {
+		case types.ExportStatusCompleted:
+			log.Printf("Archiver: export %s completed, pruning jobs older than %s", exportArn, a.retention)
+			if err := a.pruneOldJobs(ctx); err != nil {
+				return exportArn, fmt.Errorf("pruning archived jobs: %w", err)
+			}
+			return exportArn, nil
+		case types.ExportStatusFailed:
+			return exportArn, fmt.Errorf("export %s failed: %s", exportArn, aws.ToString(desc.FailureMessage))
+		}
+	}
+}
+
+// pruneOldJobs scans for jobs in a terminal state older than the retention
+// window and deletes them now that they're durably archived in S3. It pages
+// through the whole table via LastEvaluatedKey, the same way DynamoService.ListJobs
+// pages through a Query - a single Scan only returns up to 1MB of items, and the
+// hot table this is meant to shrink is exactly the case where that's not everything.
+func (a *Archiver) pruneOldJobs(ctx context.Context) error {
+	cutoff := time.Now().Add(-a.retention)
+
+	var exclusiveStartKey map[string]types.AttributeValue
+	for {
+		result, err := a.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(a.tableName),
+			ExclusiveStartKey: exclusiveStartKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Items {
+			var jobID, status string
+			var createdAt time.Time
+			if v, ok := item["job_id"]; ok {
+				if s, ok := v.(*types.AttributeValueMemberS); ok {
+					jobID = s.Value
+				}
+			}
+			if v, ok := item["status"]; ok {
+				if s, ok := v.(*types.AttributeValueMemberS); ok {
+					status = s.Value
+				}
+			}
+			if v, ok := item["created_at"]; ok {
+				if err := attributevalue.Unmarshal(v, &createdAt); err != nil {
+					continue
+				}
+			}
+
+			if !terminalStatuses[status] || createdAt.After(cutoff) {
+				continue
+			}
+
+			if _, err := a.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(a.tableName),
+				Key: map[string]types.AttributeValue{
+					"job_id": &types.AttributeValueMemberS{Value: jobID},
+				},
+			}); err != nil {
+				log.Printf("Archiver: failed to prune job %s: %v", jobID, err)
+			}
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		exclusiveStartKey = result.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,259 @@
+// services/aws/dynamo.go
+package aws
+
+import (
+	"context"
+	"distributed-classifier/backend/config"
+	"distributed-classifier/backend/models"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBAPI is the subset of *dynamodb.Client DynamoService calls, so tests
+// can inject a fake instead of talking to real DynamoDB.
+type DynamoDBAPI interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+}
+
+type DynamoService struct {
+	client    DynamoDBAPI
+	tableName string
+}
+
+// ClientOption customizes a DynamoService at construction time, e.g. to inject
+// a DynamoDBAPI fake in place of a real client.
+type ClientOption func(*DynamoService)
+
+// WithDynamoDBClient overrides the DynamoDBAPI NewDynamoService would
+// otherwise build from cfg - used by tests to inject a dynamodbiface-style fake.
+func WithDynamoDBClient(client DynamoDBAPI) ClientOption {
+	return func(d *DynamoService) {
+		d.client = client
+	}
+}
+
+func NewDynamoService(cfg *config.Config, opts ...ClientOption) *DynamoService {
+	svc := &DynamoService{tableName: cfg.TableName}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	if svc.client == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+			awsconfig.WithRegion(cfg.AWSRegion),
+			awsconfig.WithRetryer(func() aws.Retryer {
+				return retry.NewAdaptiveMode()
+			}),
+		)
+		if err != nil {
+			log.Fatalf("Failed to load AWS config: %v", err)
+		}
+
+		svc.client = dynamodb.NewFromConfig(awsCfg)
+	}
+
+	return svc
+}
+
+func (d *DynamoService) CreateJob(ctx context.Context, job *models.Job) error {
+	item, err := attributevalue.MarshalMap(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	})
+
+	return err
+}
+
+func (d *DynamoService) GetJob(ctx context.Context, jobID string) (*models.Job, error) {
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"job_id": &types.AttributeValueMemberS{Value: jobID},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Item == nil {
+		return nil, fmt.Errorf("job not found")
+	}
+
+	var job models.Job
+	err = attributevalue.UnmarshalMap(result.Item, &job)
+	return &job, err
+}
+
+func (d *DynamoService) UpdateJob(ctx context.Context, job *models.Job) error {
+	item, err := attributevalue.MarshalMap(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	})
+
+	return err
+}
+
+func (d *DynamoService) UpdateJobStatus(ctx context.Context, jobID, status, errorMsg string) error {
+	updateExpr := "SET #status = :status, updated_at = :updated_at"
+	exprAttrNames := map[string]string{
+		"#status": "status",
+	}
+	exprAttrValues := map[string]types.AttributeValue{
+		":status":     &types.AttributeValueMemberS{Value: status},
+		":updated_at": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+	}
+
+	if errorMsg != "" {
+		updateExpr += ", error = :error"
+		exprAttrValues[":error"] = &types.AttributeValueMemberS{Value: errorMsg}
+	}
+
+	_, err := d.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"job_id": &types.AttributeValueMemberS{Value: jobID},
+		},
+		UpdateExpression:          aws.String(updateExpr),
+		ExpressionAttributeNames:  exprAttrNames,
+		ExpressionAttributeValues: exprAttrValues,
+	})
+
+	return err
+}
+
+// statusCreatedAtIndex is the GSI (hash: status, range: created_at) queried when
+// ListJobs is filtered by status. allJobsIndex is the GSI (hash: entity_type,
+// range: created_at) queried otherwise - entity_type is a constant "job" written
+// on every item purely so a single GSI partition can list the whole table in
+// created_at order without a full Scan.
+const (
+	statusCreatedAtIndex = "status-created_at-index"
+	allJobsIndex         = "entity_type-created_at-index"
+	allJobsEntityType    = "job"
+)
+
+// ListJobs returns up to limit jobs ordered by created_at descending (most
+// recent first), optionally filtered by status. pageToken is an opaque,
+// base64-encoded LastEvaluatedKey from a previous call; pass "" to start from
+// the beginning. The returned nextPageToken is "" once there are no more pages.
+func (d *DynamoService) ListJobs(ctx context.Context, limit int, statusFilter, pageToken string) (jobs []*models.Job, nextPageToken string, err error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	exclusiveStartKey, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid page token: %w", err)
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:         aws.String(d.tableName),
+		Limit:             aws.Int32(int32(limit)),
+		ScanIndexForward:  aws.Bool(false), // created_at descending
+		ExclusiveStartKey: exclusiveStartKey,
+		ExpressionAttributeNames: map[string]string{
+			"#hashKey": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":hashValue": &types.AttributeValueMemberS{Value: statusFilter},
+		},
+	}
+
+	if statusFilter != "" {
+		queryInput.IndexName = aws.String(statusCreatedAtIndex)
+		queryInput.KeyConditionExpression = aws.String("#hashKey = :hashValue")
+	} else {
+		queryInput.IndexName = aws.String(allJobsIndex)
+		queryInput.KeyConditionExpression = aws.String("#hashKey = :hashValue")
+		queryInput.ExpressionAttributeNames["#hashKey"] = "entity_type"
+		queryInput.ExpressionAttributeValues[":hashValue"] = &types.AttributeValueMemberS{Value: allJobsEntityType}
+	}
+
+	result, err := d.client.Query(ctx, queryInput)
+	if err != nil {
+		return nil, "", err
+	}
+
+	jobs = make([]*models.Job, 0, len(result.Items))
+	for _, item := range result.Items {
+		var job models.Job
+		if err := attributevalue.UnmarshalMap(item, &job); err != nil {
+			continue // Skip items that can't be unmarshaled
+		}
+		jobs = append(jobs, &job)
+	}
+
+	nextPageToken, err = encodePageToken(result.LastEvaluatedKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return jobs, nextPageToken, nil
+}
+
+// encodePageToken base64-encodes a DynamoDB LastEvaluatedKey so it can be
+// handed back to the client as an opaque cursor. types.AttributeValue is an
+// interface, so it's round-tripped through attributevalue's generic Go-value
+// conversion rather than JSON-marshaled directly.
+func encodePageToken(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	var generic map[string]interface{}
+	if err := attributevalue.UnmarshalMap(key, &generic); err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// decodePageToken reverses encodePageToken, returning nil (start from the
+// beginning) when token is empty.
+func decodePageToken(token string) (map[string]types.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	return attributevalue.MarshalMap(generic)
+}
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// fakeDynamoDBAPI is a minimal in-memory DynamoDBAPI, exactly the kind the
+// WithDynamoDBClient option was added to make possible.
+type fakeDynamoDBAPI struct {
+	DynamoDBAPI
+	queryInputs []*dynamodb.QueryInput
+	pages       []*dynamodb.QueryOutput
+}
+
+func (f *fakeDynamoDBAPI) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.queryInputs = append(f.queryInputs, params)
+	page := f.pages[len(f.queryInputs)-1]
+	return page, nil
+}
+
+func jobItem(jobID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"job_id":       &types.AttributeValueMemberS{Value: jobID},
+		"entity_type":  &types.AttributeValueMemberS{Value: "job"},
+		"status":       &types.AttributeValueMemberS{Value: "completed"},
+		"job_type":     &types.AttributeValueMemberS{Value: "image_classification"},
+		"created_at":   &types.AttributeValueMemberS{Value: "2026-01-01T00:00:00Z"},
+		"updated_at":   &types.AttributeValueMemberS{Value: "2026-01-01T00:00:00Z"},
+		"input_bucket": &types.AttributeValueMemberS{Value: "s3://bucket"},
+		"s3_keys":      &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+		"retry_count":  &types.AttributeValueMemberN{Value: "0"},
+		"top_k":        &types.AttributeValueMemberN{Value: "0"},
+	}
+}
+
+func TestListJobsReturnsEmptyNextPageTokenOnLastPage(t *testing.T) {
+	fake := &fakeDynamoDBAPI{
+		pages: []*dynamodb.QueryOutput{
+			{Items: []map[string]types.AttributeValue{jobItem("job-1")}},
+		},
+	}
+	d := &DynamoService{client: fake, tableName: "jobs"}
+
+	jobs, nextPageToken, err := d.ListJobs(context.Background(), 10, "", "")
+	if err != nil {
+		t.Fatalf("ListJobs returned error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].JobID != "job-1" {
+		t.Fatalf("expected [job-1], got %v", jobs)
+	}
+	if nextPageToken != "" {
+		t.Fatalf("expected no next page token once LastEvaluatedKey is empty, got %q", nextPageToken)
+	}
+	if aws.ToString(fake.queryInputs[0].IndexName) != allJobsIndex {
+		t.Fatalf("expected query against %s when statusFilter is empty, got %s", allJobsIndex, aws.ToString(fake.queryInputs[0].IndexName))
+	}
+}
+
+func TestListJobsPageTokenRoundTripsLastEvaluatedKey(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{
+		"job_id": &types.AttributeValueMemberS{Value: "job-1"},
+	}
+	fake := &fakeDynamoDBAPI{
+		pages: []*dynamodb.QueryOutput{
+			{
+				Items:            []map[string]types.AttributeValue{jobItem("job-1")},
+				LastEvaluatedKey: lastKey,
+			},
+		},
+	}
+	d := &DynamoService{client: fake, tableName: "jobs"}
+
+	_, nextPageToken, err := d.ListJobs(context.Background(), 10, "", "")
+	if err != nil {
+		t.Fatalf("ListJobs returned error: %v", err)
+	}
+	if nextPageToken == "" {
+		t.Fatal("expected a non-empty next page token when LastEvaluatedKey is set")
+	}
+
+	// Feeding the returned token back in should round-trip to the same
+	// ExclusiveStartKey DynamoDB handed us, so the next call resumes from
+	// exactly where this one left off.
+	fake.pages = append(fake.pages, &dynamodb.QueryOutput{})
+	if _, _, err := d.ListJobs(context.Background(), 10, "", nextPageToken); err != nil {
+		t.Fatalf("ListJobs with page token returned error: %v", err)
+	}
+	gotStartKey := fake.queryInputs[1].ExclusiveStartKey
+	if s, ok := gotStartKey["job_id"].(*types.AttributeValueMemberS); !ok || s.Value != "job-1" {
+		t.Fatalf("expected ExclusiveStartKey job_id=job-1, got %v", gotStartKey)
+	}
+}
+
+func TestListJobsUsesStatusIndexWhenFiltered(t *testing.T) {
+	fake := &fakeDynamoDBAPI{
+		pages: []*dynamodb.QueryOutput{
+			{Items: []map[string]types.AttributeValue{jobItem("job-1")}},
+		},
+	}
+	d := &DynamoService{client: fake, tableName: "jobs"}
+
+	if _, _, err := d.ListJobs(context.Background(), 10, "completed", ""); err != nil {
+		t.Fatalf("ListJobs returned error: %v", err)
+	}
+	if aws.ToString(fake.queryInputs[0].IndexName) != statusCreatedAtIndex {
+		t.Fatalf("expected query against %s when statusFilter is set, got %s", statusCreatedAtIndex, aws.ToString(fake.queryInputs[0].IndexName))
+	}
+}
@@ -0,0 +1,286 @@
+package aws
+
+import (
+	"context"
+	"distributed-classifier/backend/config"
+	"distributed-classifier/backend/services/backend"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3API is the subset of *s3.Client S3Service calls, so tests can inject a
+// fake instead of talking to real S3.
+type S3API interface {
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+}
+
+// S3PresignAPI is the subset of *s3.PresignClient S3Service calls to generate
+// presigned URLs, kept separate from S3API since it's a different client type.
+type S3PresignAPI interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignUploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+type S3Service struct {
+	client  S3API
+	presign S3PresignAPI
+}
+
+// S3ClientOption customizes an S3Service at construction time, e.g. to inject
+// S3API/S3PresignAPI fakes in place of real clients.
+type S3ClientOption func(*S3Service)
+
+// WithS3API overrides the S3API NewS3Service would otherwise build from cfg -
+// used by tests to inject an s3iface-style fake.
+func WithS3API(client S3API) S3ClientOption {
+	return func(s *S3Service) {
+		s.client = client
+	}
+}
+
+// WithS3PresignAPI overrides the S3PresignAPI NewS3Service would otherwise
+// build from cfg - used by tests to inject a fake presigner.
+func WithS3PresignAPI(presign S3PresignAPI) S3ClientOption {
+	return func(s *S3Service) {
+		s.presign = presign
+	}
+}
+
+func NewS3Service(cfg *config.Config, opts ...S3ClientOption) *S3Service {
+	svc := &S3Service{}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	if svc.client == nil || svc.presign == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+			awsconfig.WithRegion(cfg.AWSRegion),
+			awsconfig.WithRetryer(func() aws.Retryer {
+				return retry.NewAdaptiveMode()
+			}),
+		)
+		if err != nil {
+			log.Fatalf("Failed to load AWS config: %v", err)
+		}
+
+		client := s3.NewFromConfig(awsCfg)
+		if svc.client == nil {
+			svc.client = client
+		}
+		if svc.presign == nil {
+			svc.presign = s3.NewPresignClient(client)
+		}
+	}
+
+	return svc
+}
+
+// bucketName strips an "s3://" scheme off a bucket identifier so callers can pass
+// either a bare bucket name or the s3:// URL form used by the ObjectStore interface.
+func bucketName(bucket string) string {
+	return strings.TrimPrefix(bucket, "s3://")
+}
+
+func (s *S3Service) CopyObject(ctx context.Context, sourceBucket, sourceKey, destBucket, destKey string) error {
+	copySource := fmt.Sprintf("%s/%s", bucketName(sourceBucket), sourceKey)
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(bucketName(destBucket)),
+		Key:        aws.String(destKey),
+		CopySource: aws.String(copySource),
+	})
+
+	return err
+}
+
+func (s *S3Service) GetPresignedURL(ctx context.Context, bucket, key string, expiration time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucketName(bucket)),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiration))
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL, nil
+}
+
+// GetPresignedUploadURL generates a presigned PUT URL for uploading to S3
+func (s *S3Service) GetPresignedUploadURL(ctx context.Context, bucket, key, contentType string, expiration time.Duration) (string, error) {
+	req, err := s.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName(bucket)),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(expiration))
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL, nil
+}
+
+// ListObjects lists all objects in the specified bucket with optional prefix
+func (s *S3Service) ListObjects(ctx context.Context, bucket, prefix string) ([]backend.ObjectInfo, error) {
+	var objects []backend.ObjectInfo
+	var continuationToken *string
+
+	for {
+		input := &s3.ListObjectsV2Input{
+			Bucket: aws.String(bucketName(bucket)),
+		}
+
+		if prefix != "" {
+			input.Prefix = aws.String(prefix)
+		}
+
+		if continuationToken != nil {
+			input.ContinuationToken = continuationToken
+		}
+
+		result, err := s.client.ListObjectsV2(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range result.Contents {
+			objects = append(objects, backend.ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+
+		if !aws.ToBool(result.IsTruncated) {
+			break
+		}
+
+		continuationToken = result.NextContinuationToken
+	}
+
+	return objects, nil
+}
+
+// DeleteObject deletes an object from S3
+func (s *S3Service) DeleteObject(ctx context.Context, bucket, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bucketName(bucket)),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+// InitiateMultipartUpload starts a new S3 multipart upload and returns its UploadID
+func (s *S3Service) InitiateMultipartUpload(ctx context.Context, bucket, key, contentType string) (string, error) {
+	result, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(bucketName(bucket)),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(result.UploadId), nil
+}
+
+// GetPresignedUploadPartURL generates a presigned PUT URL for a single part of a
+// multipart upload so the client can stream that chunk directly to S3.
+func (s *S3Service) GetPresignedUploadPartURL(ctx context.Context, bucket, key, uploadID string, partNumber int64, expiration time.Duration) (string, error) {
+	req, err := s.presign.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(bucketName(bucket)),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(int32(partNumber)),
+	}, s3.WithPresignExpires(expiration))
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL, nil
+}
+
+// CompletePresignedMultipartUpload finalizes a multipart upload once every part has
+// been PUT to its presigned URL and the client has collected their ETags.
+func (s *S3Service) CompletePresignedMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []backend.CompletedPart) error {
+	completedParts := make([]types.CompletedPart, 0, len(parts))
+	for _, p := range parts {
+		completedParts = append(completedParts, types.CompletedPart{
+			PartNumber: aws.Int32(int32(p.PartNumber)),
+			ETag:       aws.String(p.ETag),
+		})
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucketName(bucket)),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	})
+
+	return err
+}
+
+// AbortMultipartUpload cancels an in-progress multipart upload and releases any
+// parts already stored against it, so incomplete uploads don't leak storage cost.
+func (s *S3Service) AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucketName(bucket)),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+
+	return err
+}
+
+// ListMultipartUploads returns every in-progress multipart upload in the bucket, used
+// by the janitor to find uploads that have been abandoned past their TTL.
+func (s *S3Service) ListMultipartUploads(ctx context.Context, bucket string) ([]backend.MultipartUploadInfo, error) {
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucketName(bucket)),
+	}
+
+	var uploads []backend.MultipartUploadInfo
+	for {
+		result, err := s.client.ListMultipartUploads(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range result.Uploads {
+			uploads = append(uploads, backend.MultipartUploadInfo{
+				Key:       aws.ToString(u.Key),
+				UploadID:  aws.ToString(u.UploadId),
+				Initiated: aws.ToTime(u.Initiated),
+			})
+		}
+
+		if !aws.ToBool(result.IsTruncated) {
+			break
+		}
+
+		input.KeyMarker = result.NextKeyMarker
+		input.UploadIdMarker = result.NextUploadIdMarker
+	}
+
+	return uploads, nil
+}
@@ -0,0 +1,163 @@
+package aws
+
+import (
+	"context"
+	"distributed-classifier/backend/config"
+	"distributed-classifier/backend/services/backend"
+	"encoding/base64"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// SQSAPI is the subset of *sqs.Client SQSService calls, so tests can inject a
+// fake instead of talking to real SQS.
+type SQSAPI interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+}
+
+// SQSService implements backend.MessageQueue against AWS SQS.
+type SQSService struct {
+	client SQSAPI
+}
+
+// SQSClientOption customizes an SQSService at construction time, e.g. to
+// inject an SQSAPI fake in place of a real client.
+type SQSClientOption func(*SQSService)
+
+// WithSQSAPI overrides the SQSAPI NewSQSService would otherwise build from
+// cfg - used by tests to inject an sqsiface-style fake.
+func WithSQSAPI(client SQSAPI) SQSClientOption {
+	return func(s *SQSService) {
+		s.client = client
+	}
+}
+
+func NewSQSService(cfg *config.Config, opts ...SQSClientOption) *SQSService {
+	svc := &SQSService{}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	if svc.client == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+			awsconfig.WithRegion(cfg.AWSRegion),
+			awsconfig.WithRetryer(func() aws.Retryer {
+				return retry.NewAdaptiveMode()
+			}),
+		)
+		if err != nil {
+			log.Fatalf("Failed to load AWS config: %v", err)
+		}
+
+		svc.client = sqs.NewFromConfig(awsCfg)
+	}
+
+	return svc
+}
+
+// attributeNames are the SQS message attributes requested on every receive -
+// content_type (set by backend.Publish) plus job_id and s3_key when the sender
+// set them - so callers can dispatch on them without a second round trip.
+var attributeNames = []string{"job_id", "s3_key", "content_type"}
+
+// SendMessage base64-encodes body before handing it to SQS: an SQS message
+// body must be valid UTF-8 (the API rejects anything else, per
+// https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_SendMessage.html),
+// and AvroCodec/ProtoCodec produce arbitrary binary data that won't satisfy
+// that on its own. JSONCodec output happens to already be valid UTF-8, but
+// encoding unconditionally keeps every codec on the same wire format instead
+// of special-casing JSON.
+func (s *SQSService) SendMessage(ctx context.Context, queueURL string, body []byte, attributes map[string]string) error {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queueURL),
+		MessageBody: aws.String(base64.StdEncoding.EncodeToString(body)),
+	}
+
+	if len(attributes) > 0 {
+		input.MessageAttributes = make(map[string]types.MessageAttributeValue, len(attributes))
+		for k, v := range attributes {
+			input.MessageAttributes[k] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(v),
+			}
+		}
+	}
+
+	_, err := s.client.SendMessage(ctx, input)
+	return err
+}
+
+func (s *SQSService) ReceiveMessages(ctx context.Context, queueURL string) ([]backend.Message, error) {
+	result, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: 10,
+		WaitTimeSeconds:     20,  // Long polling
+		VisibilityTimeout:   300, // 5 minutes
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+			types.MessageSystemAttributeNameApproximateReceiveCount,
+		},
+		MessageAttributeNames: attributeNames,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]backend.Message, 0, len(result.Messages))
+	for _, msg := range result.Messages {
+		body, err := base64.StdEncoding.DecodeString(aws.ToString(msg.Body))
+		if err != nil {
+			log.Printf("Received SQS message with non-base64 body, discarding: %v", err)
+			continue
+		}
+
+		m := backend.Message{
+			Body:              string(body),
+			ReceiptHandle:     aws.ToString(msg.ReceiptHandle),
+			Attributes:        make(map[string]string, len(msg.Attributes)),
+			MessageAttributes: make(map[string]string, len(msg.MessageAttributes)),
+		}
+
+		for k, v := range msg.Attributes {
+			m.Attributes[k] = v
+		}
+		for k, v := range msg.MessageAttributes {
+			m.MessageAttributes[k] = aws.ToString(v.StringValue)
+		}
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
+
+func (s *SQSService) DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error {
+	_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+
+	return err
+}
+
+// ChangeMessageVisibility extends how long a message stays invisible after a
+// transient failure, giving the downstream system time to recover before SQS
+// redelivers it. Callers typically pass an exponential backoff in seconds.
+func (s *SQSService) ChangeMessageVisibility(ctx context.Context, queueURL string, receiptHandle string, visibilityTimeoutSeconds int64) error {
+	_, err := s.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(queueURL),
+		ReceiptHandle:     aws.String(receiptHandle),
+		VisibilityTimeout: int32(visibilityTimeoutSeconds),
+	})
+
+	return err
+}
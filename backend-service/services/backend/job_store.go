@@ -0,0 +1,25 @@
+package backend
+
+import (
+	"context"
+	"distributed-classifier/backend/models"
+)
+
+// JobStore abstracts the job system-of-record so handlers don't depend on any
+// single database directly. services/aws.DynamoService satisfies this interface
+// against DynamoDB; services/natspg.PostgresJobStore satisfies it against
+// Postgres for the BACKEND=nats_pg deployment. Every method takes the calling
+// request's context so a client disconnect or per-request timeout aborts the
+// underlying call instead of running to completion unobserved.
+type JobStore interface {
+	CreateJob(ctx context.Context, job *models.Job) error
+	GetJob(ctx context.Context, jobID string) (*models.Job, error)
+	UpdateJob(ctx context.Context, job *models.Job) error
+	UpdateJobStatus(ctx context.Context, jobID, status, errorMsg string) error
+
+	// ListJobs returns up to limit jobs ordered by created_at descending,
+	// optionally filtered by status. pageToken is an opaque cursor from a
+	// previous call ("" to start from the beginning); the returned
+	// nextPageToken is "" once there are no more pages.
+	ListJobs(ctx context.Context, limit int, statusFilter, pageToken string) (jobs []*models.Job, nextPageToken string, err error)
+}
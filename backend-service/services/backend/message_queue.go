@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+	"strconv"
+)
+
+// Message is a driver-agnostic stand-in for a received queue message so
+// handlers don't depend on *sqs.Message directly. services/aws.SQSService
+// populates it from SQS; services/natspg.NATSMessageQueue populates it from a
+// JetStream message.
+type Message struct {
+	Body          string
+	ReceiptHandle string
+
+	// Attributes holds queue system attributes, e.g. "ApproximateReceiveCount".
+	Attributes map[string]string
+
+	// MessageAttributes holds attributes the publisher set, e.g. "content_type".
+	MessageAttributes map[string]string
+}
+
+// MessageQueue abstracts the request/status queue so handlers don't depend on
+// any single queueing system directly. services/aws.SQSService satisfies this
+// interface against SQS; services/natspg.NATSMessageQueue satisfies it against
+// a NATS JetStream stream with durable consumers for the BACKEND=nats_pg
+// deployment. Every method takes the calling request's context so a client
+// disconnect or per-request timeout aborts the underlying call.
+type MessageQueue interface {
+	SendMessage(ctx context.Context, queueURL string, body []byte, attributes map[string]string) error
+	ReceiveMessages(ctx context.Context, queueURL string) ([]Message, error)
+	DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error
+	ChangeMessageVisibility(ctx context.Context, queueURL string, receiptHandle string, visibilityTimeoutSeconds int64) error
+}
+
+// ApproximateReceiveCount reads the ApproximateReceiveCount system attribute
+// off a message, defaulting to 1 if the attribute wasn't requested or is
+// missing.
+func ApproximateReceiveCount(msg Message) int {
+	raw, ok := msg.Attributes["ApproximateReceiveCount"]
+	if !ok || raw == "" {
+		return 1
+	}
+
+	count, err := strconv.Atoi(raw)
+	if err != nil || count < 1 {
+		return 1
+	}
+
+	return count
+}
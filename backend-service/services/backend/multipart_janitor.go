@@ -0,0 +1,59 @@
+package backend
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// MultipartJanitor periodically aborts multipart uploads that have been sitting
+// in the bucket longer than TTL, so a client that never completes (or crashes
+// mid-upload) doesn't leave orphaned parts accruing storage cost forever.
+type MultipartJanitor struct {
+	store  ObjectStore
+	bucket string
+	ttl    time.Duration
+}
+
+func NewMultipartJanitor(store ObjectStore, bucket string, ttl time.Duration) *MultipartJanitor {
+	return &MultipartJanitor{
+		store:  store,
+		bucket: bucket,
+		ttl:    ttl,
+	}
+}
+
+// Run sweeps the bucket for stale multipart uploads on the given interval. It
+// blocks, so callers should invoke it via `go janitor.Run(interval)`.
+func (j *MultipartJanitor) Run(interval time.Duration) {
+	log.Printf("Starting multipart upload janitor (ttl=%s, interval=%s)", j.ttl, interval)
+
+	for {
+		j.sweep()
+		time.Sleep(interval)
+	}
+}
+
+func (j *MultipartJanitor) sweep() {
+	// The janitor runs on its own timer rather than in response to a request,
+	// so it has no caller context to propagate - each sweep gets a fresh one.
+	ctx := context.Background()
+
+	uploads, err := j.store.ListMultipartUploads(ctx, j.bucket)
+	if err != nil {
+		log.Printf("Janitor: failed to list multipart uploads: %v", err)
+		return
+	}
+
+	cutoff := time.Now().Add(-j.ttl)
+	for _, upload := range uploads {
+		if upload.Initiated.IsZero() || upload.Initiated.After(cutoff) {
+			continue
+		}
+
+		log.Printf("Janitor: aborting stale multipart upload %s for key %s (initiated %s)", upload.UploadID, upload.Key, upload.Initiated)
+		if err := j.store.AbortMultipartUpload(ctx, j.bucket, upload.Key, upload.UploadID); err != nil {
+			log.Printf("Janitor: failed to abort upload %s: %v", upload.UploadID, err)
+		}
+	}
+}
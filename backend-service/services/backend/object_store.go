@@ -0,0 +1,48 @@
+package backend
+
+import (
+	"context"
+	"time"
+)
+
+// ObjectStore abstracts the blob operations the pipeline needs so handlers don't
+// depend on any single cloud SDK directly. services/aws.S3Service satisfies this
+// interface against AWS S3; services.GoCloudBlobService satisfies it against any
+// gocloud.dev/blob driver (GCS, Azure Blob, or a local file:// bucket for
+// development). Every method takes the calling request's context so a client
+// disconnect or per-request timeout aborts the underlying call.
+type ObjectStore interface {
+	CopyObject(ctx context.Context, sourceBucket, sourceKey, destBucket, destKey string) error
+	GetPresignedURL(ctx context.Context, bucket, key string, expiration time.Duration) (string, error)
+	GetPresignedUploadURL(ctx context.Context, bucket, key, contentType string, expiration time.Duration) (string, error)
+	ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+
+	InitiateMultipartUpload(ctx context.Context, bucket, key, contentType string) (string, error)
+	GetPresignedUploadPartURL(ctx context.Context, bucket, key, uploadID string, partNumber int64, expiration time.Duration) (string, error)
+	CompletePresignedMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error
+	ListMultipartUploads(ctx context.Context, bucket string) ([]MultipartUploadInfo, error)
+}
+
+// ObjectInfo is a driver-agnostic stand-in for *s3.Object so callers outside
+// services/aws never need to import the AWS SDK.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// MultipartUploadInfo is a driver-agnostic stand-in for *s3.MultipartUpload
+type MultipartUploadInfo struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+}
+
+// CompletedPart is an ETag/PartNumber pair returned once a multipart upload
+// part finishes uploading.
+type CompletedPart struct {
+	PartNumber int64
+	ETag       string
+}
@@ -0,0 +1,45 @@
+package backend
+
+import "context"
+
+// contentTypeAttribute is the message attribute Publish attaches so consumers
+// can dispatch on the codec without sniffing the body.
+const contentTypeAttribute = "content_type"
+
+// Publish marshals message with codec and sends it to queueURL via q,
+// attaching the codec's content type as a message attribute. Callers that
+// know their message type at compile time should prefer this over a raw
+// SendMessage call.
+func Publish[T any](ctx context.Context, q MessageQueue, codec Codec, queueURL string, message T) error {
+	body, err := codec.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return q.SendMessage(ctx, queueURL, body, map[string]string{
+		contentTypeAttribute: codec.ContentType(),
+	})
+}
+
+// Consume decodes msg's body into a T using codec.
+func Consume[T any](codec Codec, msg Message) (T, error) {
+	var v T
+	err := codec.Unmarshal([]byte(msg.Body), &v)
+	return v, err
+}
+
+// SendToDeadLetterQueue republishes msg's original body to queueURL via q once
+// a RetryPolicy has been exhausted, attaching attrs (typically last_error,
+// stack, and job_id) as message attributes so the body itself doesn't need
+// reparsing to triage the failure.
+func SendToDeadLetterQueue(ctx context.Context, q MessageQueue, queueURL string, msg Message, attrs map[string]string) error {
+	merged := make(map[string]string, len(msg.MessageAttributes)+len(attrs))
+	for k, v := range msg.MessageAttributes {
+		merged[k] = v
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+
+	return q.SendMessage(ctx, queueURL, []byte(msg.Body), merged)
+}
@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy governs how many times a message is allowed to fail before it
+// is considered permanently failed, and how long to back off between
+// redeliveries via ChangeMessageVisibility. Backoff grows geometrically:
+// min(MaxBackoff, InitialBackoff * Multiplier^(attempt-1)) +/- Jitter.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         time.Duration
+}
+
+// DefaultRetryPolicy matches the backoff this package used before RetryPolicy
+// existed: 2s, 8s, 32s, ... capped at 5 minutes, with no jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+		Multiplier:     4,
+	}
+}
+
+// Backoff returns how long to hide a message from redelivery after the
+// attempt'th failed receive (attempt is the message's ApproximateReceiveCount,
+// 1-based).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if max := float64(p.MaxBackoff); p.MaxBackoff > 0 && backoff > max {
+		backoff = max
+	}
+
+	d := time.Duration(backoff)
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Int63n(2*int64(p.Jitter)+1)) - p.Jitter
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	return d
+}
+
+// Exhausted reports whether attempt has used up the policy's retry budget and
+// the message should be moved to a dead-letter queue instead of retried again.
+func (p RetryPolicy) Exhausted(attempt int) bool {
+	return attempt >= p.MaxAttempts
+}
@@ -0,0 +1,51 @@
+package backend
+
+import "testing"
+
+func TestRetryPolicyBackoffGrowsGeometricallyAndCaps(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	cases := []struct {
+		attempt  int
+		expected string
+	}{
+		{1, "2s"},
+		{2, "8s"},
+		{3, "32s"},
+		{10, "5m0s"}, // would be far past MaxBackoff uncapped
+	}
+
+	for _, c := range cases {
+		if got := p.Backoff(c.attempt).String(); got != c.expected {
+			t.Errorf("Backoff(%d) = %s, want %s", c.attempt, got, c.expected)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffTreatsSubOneAttemptAsFirst(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	if got, want := p.Backoff(0), p.Backoff(1); got != want {
+		t.Errorf("Backoff(0) = %s, want same as Backoff(1) = %s", got, want)
+	}
+}
+
+func TestRetryPolicyExhausted(t *testing.T) {
+	p := DefaultRetryPolicy() // MaxAttempts: 3
+
+	cases := []struct {
+		attempt  int
+		expected bool
+	}{
+		{1, false},
+		{2, false},
+		{3, true},
+		{4, true},
+	}
+
+	for _, c := range cases {
+		if got := p.Exhausted(c.attempt); got != c.expected {
+			t.Errorf("Exhausted(%d) = %v, want %v", c.attempt, got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"distributed-classifier/backend/services/backend"
+
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/azureblob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
+)
+
+// GoCloudBlobService implements backend.ObjectStore on top of gocloud.dev/blob, so the
+// pipeline can run against GCS, Azure Blob, or a local file:// bucket in addition
+// to S3 without any code in handlers/ changing. Buckets are opened lazily and
+// cached by URL so repeated calls against the same bucket reuse the connection.
+// Every handler runs in its own goroutine and MultipartJanitor polls the same
+// instance on a timer, so bucketsMu guards concurrent first-time opens of the
+// buckets map.
+type GoCloudBlobService struct {
+	bucketsMu sync.Mutex
+	buckets   map[string]*blob.Bucket
+}
+
+func NewGoCloudBlobService() *GoCloudBlobService {
+	return &GoCloudBlobService{
+		buckets: make(map[string]*blob.Bucket),
+	}
+}
+
+// openBucket resolves a bucket identifier to a *blob.Bucket. Handlers pass a
+// full gocloud URL (e.g. "gs://my-bucket") taken from
+// config.Config.InputBucketURL/OutputBucketURL, not a plain bucket name.
+func (g *GoCloudBlobService) openBucket(bucketURL string) (*blob.Bucket, error) {
+	g.bucketsMu.Lock()
+	defer g.bucketsMu.Unlock()
+
+	if b, ok := g.buckets[bucketURL]; ok {
+		return b, nil
+	}
+
+	b, err := blob.OpenBucket(context.Background(), bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening bucket %s: %w", bucketURL, err)
+	}
+
+	g.buckets[bucketURL] = b
+	return b, nil
+}
+
+func (g *GoCloudBlobService) CopyObject(ctx context.Context, sourceBucket, sourceKey, destBucket, destKey string) error {
+	src, err := g.openBucket(sourceBucket)
+	if err != nil {
+		return err
+	}
+	dst, err := g.openBucket(destBucket)
+	if err != nil {
+		return err
+	}
+
+	if src == dst || sourceBucket == destBucket {
+		return src.Copy(ctx, destKey, sourceKey, nil)
+	}
+
+	reader, err := src.NewReader(ctx, sourceKey, nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := dst.NewWriter(ctx, destKey, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (g *GoCloudBlobService) GetPresignedURL(ctx context.Context, bucketURL, key string, expiration time.Duration) (string, error) {
+	b, err := g.openBucket(bucketURL)
+	if err != nil {
+		return "", err
+	}
+
+	return b.SignedURL(ctx, key, &blob.SignedURLOptions{
+		Expiry: expiration,
+		Method: "GET",
+	})
+}
+
+func (g *GoCloudBlobService) GetPresignedUploadURL(ctx context.Context, bucketURL, key, contentType string, expiration time.Duration) (string, error) {
+	b, err := g.openBucket(bucketURL)
+	if err != nil {
+		return "", err
+	}
+
+	return b.SignedURL(ctx, key, &blob.SignedURLOptions{
+		Expiry:      expiration,
+		Method:      "PUT",
+		ContentType: contentType,
+	})
+}
+
+func (g *GoCloudBlobService) ListObjects(ctx context.Context, bucketURL, prefix string) ([]backend.ObjectInfo, error) {
+	b, err := g.openBucket(bucketURL)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := b.List(&blob.ListOptions{Prefix: prefix})
+
+	var objects []backend.ObjectInfo
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, backend.ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			LastModified: obj.ModTime,
+		})
+	}
+
+	return objects, nil
+}
+
+func (g *GoCloudBlobService) DeleteObject(ctx context.Context, bucketURL, key string) error {
+	b, err := g.openBucket(bucketURL)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(ctx, key)
+}
+
+// errMultipartUnsupported is returned by every multipart method of
+// GoCloudBlobService, whatever the bucket's scheme; multipart uploads are only
+// served for s3:// buckets, which newObjectStore routes to aws.S3Service.
+var errMultipartUnsupported = errors.New("multipart upload is only supported against an s3:// bucket URL")
+
+func (g *GoCloudBlobService) InitiateMultipartUpload(ctx context.Context, bucketURL, key, contentType string) (string, error) {
+	if !strings.HasPrefix(bucketURL, "s3://") {
+		return "", errMultipartUnsupported
+	}
+	return "", errMultipartUnsupported
+}
+
+func (g *GoCloudBlobService) GetPresignedUploadPartURL(ctx context.Context, bucketURL, key, uploadID string, partNumber int64, expiration time.Duration) (string, error) {
+	return "", errMultipartUnsupported
+}
+
+func (g *GoCloudBlobService) CompletePresignedMultipartUpload(ctx context.Context, bucketURL, key, uploadID string, parts []backend.CompletedPart) error {
+	return errMultipartUnsupported
+}
+
+func (g *GoCloudBlobService) AbortMultipartUpload(ctx context.Context, bucketURL, key, uploadID string) error {
+	return errMultipartUnsupported
+}
+
+func (g *GoCloudBlobService) ListMultipartUploads(ctx context.Context, bucketURL string) ([]backend.MultipartUploadInfo, error) {
+	return nil, errMultipartUnsupported
+}
@@ -0,0 +1,201 @@
+package natspg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"distributed-classifier/backend/models"
+
+	_ "github.com/lib/pq"
+)
+
+// schemaDDL mirrors the table sqlc would generate queries against from a
+// query.sql / schema.sql pair. The job itself is kept as a JSONB blob so this
+// store doesn't need a migration every time models.Job grows a field; status
+// and created_at are promoted to real columns purely so they can be indexed
+// for ListJobs, matching how the status-created_at GSI works on the DynamoDB
+// side.
+const schemaDDL = `
+CREATE TABLE IF NOT EXISTS jobs (
+	job_id     TEXT PRIMARY KEY,
+	status     TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS jobs_status_created_at_idx ON jobs (status, created_at DESC, job_id DESC);
+CREATE INDEX IF NOT EXISTS jobs_created_at_idx ON jobs (created_at DESC, job_id DESC);
+`
+
+// PostgresJobStore implements backend.JobStore against Postgres, replacing
+// DynamoDB when BACKEND=nats_pg.
+type PostgresJobStore struct {
+	db *sql.DB
+}
+
+func NewPostgresJobStore(databaseURL string) (*PostgresJobStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("pinging postgres: %w", err)
+	}
+
+	if _, err := db.Exec(schemaDDL); err != nil {
+		return nil, fmt.Errorf("applying jobs schema: %w", err)
+	}
+
+	return &PostgresJobStore{db: db}, nil
+}
+
+func (p *PostgresJobStore) CreateJob(ctx context.Context, job *models.Job) error {
+	return p.putJob(ctx, job)
+}
+
+func (p *PostgresJobStore) UpdateJob(ctx context.Context, job *models.Job) error {
+	return p.putJob(ctx, job)
+}
+
+func (p *PostgresJobStore) putJob(ctx context.Context, job *models.Job) error {
+	doc, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO jobs (job_id, status, created_at, doc)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (job_id) DO UPDATE SET status = $2, doc = $4
+	`, job.JobID, job.Status, job.CreatedAt, doc)
+
+	return err
+}
+
+func (p *PostgresJobStore) GetJob(ctx context.Context, jobID string) (*models.Job, error) {
+	var doc []byte
+	err := p.db.QueryRowContext(ctx, `SELECT doc FROM jobs WHERE job_id = $1`, jobID).Scan(&doc)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job models.Job
+	if err := json.Unmarshal(doc, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (p *PostgresJobStore) UpdateJobStatus(ctx context.Context, jobID, status, errorMsg string) error {
+	job, err := p.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	job.Status = status
+	job.UpdatedAt = time.Now()
+	if errorMsg != "" {
+		job.Error = errorMsg
+	}
+
+	return p.putJob(ctx, job)
+}
+
+// jobPageCursor is the decoded form of a ListJobs pageToken: the
+// (created_at, job_id) of the last row returned, used for keyset pagination
+// instead of OFFSET so pages stay stable as new jobs are inserted.
+type jobPageCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	JobID     string    `json:"job_id"`
+}
+
+func (p *PostgresJobStore) ListJobs(ctx context.Context, limit int, statusFilter, pageToken string) ([]*models.Job, string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	cursor, err := decodeJobPageCursor(pageToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid page token: %w", err)
+	}
+
+	query := `SELECT doc FROM jobs WHERE ($1 = '' OR status = $1)`
+	args := []interface{}{statusFilter}
+
+	if cursor != nil {
+		query += fmt.Sprintf(` AND (created_at, job_id) < ($%d, $%d)`, len(args)+1, len(args)+2)
+		args = append(args, cursor.CreatedAt, cursor.JobID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY created_at DESC, job_id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	jobs := make([]*models.Job, 0, limit)
+	for rows.Next() {
+		var doc []byte
+		if err := rows.Scan(&doc); err != nil {
+			continue
+		}
+
+		var job models.Job
+		if err := json.Unmarshal(doc, &job); err != nil {
+			continue
+		}
+
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(jobs) == limit {
+		last := jobs[len(jobs)-1]
+		nextPageToken, err = encodeJobPageCursor(jobPageCursor{CreatedAt: last.CreatedAt, JobID: last.JobID})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return jobs, nextPageToken, nil
+}
+
+func encodeJobPageCursor(c jobPageCursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+func decodeJobPageCursor(token string) (*jobPageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var c jobPageCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
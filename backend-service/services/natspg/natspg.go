@@ -0,0 +1,6 @@
+// Package natspg provides the BACKEND=nats_pg implementation of
+// services/backend's JobStore and MessageQueue interfaces: NATS JetStream
+// durable consumers in place of SQS, and Postgres in place of DynamoDB. It
+// lets the system run fully on-prem, with no AWS dependency, for local
+// development or self-hosted deployments.
+package natspg
@@ -0,0 +1,184 @@
+package natspg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"distributed-classifier/backend/services/backend"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSMessageQueue implements backend.MessageQueue on a NATS JetStream stream
+// with one durable consumer per subject, replacing the SQS status and request
+// queues when BACKEND=nats_pg. A queueURL in this interface is a NATS subject
+// under the stream's root, e.g. "status" or "requests", rather than an SQS
+// queue URL.
+type NATSMessageQueue struct {
+	js         jetstream.JetStream
+	streamName string
+	ackWait    time.Duration
+
+	mu        sync.Mutex
+	consumers map[string]jetstream.Consumer
+	inFlight  map[string]jetstream.Msg // receiptHandle -> unacked message
+}
+
+func NewNATSMessageQueue(natsURL, streamName string) (*NATSMessageQueue, error) {
+	nc, err := nats.Connect(natsURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("creating JetStream context: %w", err)
+	}
+
+	ctx := context.Background()
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     streamName,
+		Subjects: []string{streamName + ".>"},
+	}); err != nil {
+		return nil, fmt.Errorf("creating stream %s: %w", streamName, err)
+	}
+
+	return &NATSMessageQueue{
+		js:         js,
+		streamName: streamName,
+		ackWait:    5 * time.Minute,
+		consumers:  make(map[string]jetstream.Consumer),
+		inFlight:   make(map[string]jetstream.Msg),
+	}, nil
+}
+
+func (q *NATSMessageQueue) subject(queueURL string) string {
+	return q.streamName + "." + queueURL
+}
+
+func (q *NATSMessageQueue) durableName(queueURL string) string {
+	return strings.ReplaceAll(queueURL, ".", "_")
+}
+
+func (q *NATSMessageQueue) consumer(ctx context.Context, queueURL string) (jetstream.Consumer, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if c, ok := q.consumers[queueURL]; ok {
+		return c, nil
+	}
+
+	c, err := q.js.CreateOrUpdateConsumer(ctx, q.streamName, jetstream.ConsumerConfig{
+		Durable:       q.durableName(queueURL),
+		FilterSubject: q.subject(queueURL),
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       q.ackWait,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q.consumers[queueURL] = c
+	return c, nil
+}
+
+func (q *NATSMessageQueue) SendMessage(ctx context.Context, queueURL string, body []byte, attributes map[string]string) error {
+	header := nats.Header{}
+	for k, v := range attributes {
+		header.Set(k, v)
+	}
+
+	_, err := q.js.PublishMsg(ctx, &nats.Msg{
+		Subject: q.subject(queueURL),
+		Data:    body,
+		Header:  header,
+	})
+
+	return err
+}
+
+// ReceiveMessages long-polls the durable consumer for queueURL, mirroring the
+// SQS ReceiveMessages call it replaces: up to 10 messages per fetch, waiting
+// up to 20 seconds for at least one to arrive. ctx bounds only the consumer
+// lookup; the fetch itself is not cut short when ctx is cancelled.
+func (q *NATSMessageQueue) ReceiveMessages(ctx context.Context, queueURL string) ([]backend.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	c, err := q.consumer(ctx, queueURL)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := c.Fetch(10, jetstream.FetchMaxWait(20*time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []backend.Message
+	for msg := range batch.Messages() {
+		receiveCount := 1
+		if meta, err := msg.Metadata(); err == nil {
+			receiveCount = int(meta.NumDelivered)
+		}
+
+		receiptHandle := uuid.New().String()
+		q.mu.Lock()
+		q.inFlight[receiptHandle] = msg
+		q.mu.Unlock()
+
+		messageAttributes := make(map[string]string, len(msg.Headers()))
+		for k := range msg.Headers() {
+			messageAttributes[k] = msg.Headers().Get(k)
+		}
+
+		messages = append(messages, backend.Message{
+			Body:          string(msg.Data()),
+			ReceiptHandle: receiptHandle,
+			Attributes: map[string]string{
+				"ApproximateReceiveCount": fmt.Sprintf("%d", receiveCount),
+			},
+			MessageAttributes: messageAttributes,
+		})
+	}
+
+	if err := batch.Error(); err != nil && len(messages) == 0 {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (q *NATSMessageQueue) takeInFlight(receiptHandle string) (jetstream.Msg, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	msg, ok := q.inFlight[receiptHandle]
+	if ok {
+		delete(q.inFlight, receiptHandle)
+	}
+	return msg, ok
+}
+
+func (q *NATSMessageQueue) DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error {
+	msg, ok := q.takeInFlight(receiptHandle)
+	if !ok {
+		return fmt.Errorf("no in-flight message for receipt handle %s", receiptHandle)
+	}
+	return msg.Ack()
+}
+
+// ChangeMessageVisibility maps onto JetStream's nak-with-delay: the message is
+// redelivered after visibilityTimeoutSeconds instead of immediately.
+func (q *NATSMessageQueue) ChangeMessageVisibility(ctx context.Context, queueURL string, receiptHandle string, visibilityTimeoutSeconds int64) error {
+	msg, ok := q.takeInFlight(receiptHandle)
+	if !ok {
+		return fmt.Errorf("no in-flight message for receipt handle %s", receiptHandle)
+	}
+	return msg.NakWithDelay(time.Duration(visibilityTimeoutSeconds) * time.Second)
+}
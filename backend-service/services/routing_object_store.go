@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"distributed-classifier/backend/config"
+	"distributed-classifier/backend/services/backend"
+)
+
+// RoutingObjectStore implements backend.ObjectStore by dispatching each call to
+// whichever backend matches the scheme of the bucket URL(s) involved, instead
+// of forcing one backend onto every bucket. That's what lets InputBucketURL and
+// OutputBucketURL point at different clouds (e.g. INPUT_BUCKET_URL=s3://... with
+// OUTPUT_BUCKET_URL=gs://...): s3:// buckets go through s3Svc (needed for
+// presigned multipart uploads, which gocloud.dev/blob doesn't support), and
+// every other scheme goes through fallback.
+type RoutingObjectStore struct {
+	s3Svc    backend.ObjectStore
+	fallback backend.ObjectStore
+}
+
+func NewRoutingObjectStore(s3Svc, fallback backend.ObjectStore) *RoutingObjectStore {
+	return &RoutingObjectStore{s3Svc: s3Svc, fallback: fallback}
+}
+
+func (r *RoutingObjectStore) storeFor(bucketURL string) backend.ObjectStore {
+	if config.IsS3BucketURL(bucketURL) {
+		return r.s3Svc
+	}
+	return r.fallback
+}
+
+// CopyObject routes a same-backend copy to that backend's native CopyObject,
+// and falls back to streaming the copy through gocloud.dev/blob (which can open
+// either side by its own scheme) when the source and destination disagree.
+func (r *RoutingObjectStore) CopyObject(ctx context.Context, sourceBucket, sourceKey, destBucket, destKey string) error {
+	if config.IsS3BucketURL(sourceBucket) && config.IsS3BucketURL(destBucket) {
+		return r.s3Svc.CopyObject(ctx, sourceBucket, sourceKey, destBucket, destKey)
+	}
+	return r.fallback.CopyObject(ctx, sourceBucket, sourceKey, destBucket, destKey)
+}
+
+func (r *RoutingObjectStore) GetPresignedURL(ctx context.Context, bucket, key string, expiration time.Duration) (string, error) {
+	return r.storeFor(bucket).GetPresignedURL(ctx, bucket, key, expiration)
+}
+
+func (r *RoutingObjectStore) GetPresignedUploadURL(ctx context.Context, bucket, key, contentType string, expiration time.Duration) (string, error) {
+	return r.storeFor(bucket).GetPresignedUploadURL(ctx, bucket, key, contentType, expiration)
+}
+
+func (r *RoutingObjectStore) ListObjects(ctx context.Context, bucket, prefix string) ([]backend.ObjectInfo, error) {
+	return r.storeFor(bucket).ListObjects(ctx, bucket, prefix)
+}
+
+func (r *RoutingObjectStore) DeleteObject(ctx context.Context, bucket, key string) error {
+	return r.storeFor(bucket).DeleteObject(ctx, bucket, key)
+}
+
+func (r *RoutingObjectStore) InitiateMultipartUpload(ctx context.Context, bucket, key, contentType string) (string, error) {
+	return r.storeFor(bucket).InitiateMultipartUpload(ctx, bucket, key, contentType)
+}
+
+func (r *RoutingObjectStore) GetPresignedUploadPartURL(ctx context.Context, bucket, key, uploadID string, partNumber int64, expiration time.Duration) (string, error) {
+	return r.storeFor(bucket).GetPresignedUploadPartURL(ctx, bucket, key, uploadID, partNumber, expiration)
+}
+
+func (r *RoutingObjectStore) CompletePresignedMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []backend.CompletedPart) error {
+	return r.storeFor(bucket).CompletePresignedMultipartUpload(ctx, bucket, key, uploadID, parts)
+}
+
+func (r *RoutingObjectStore) AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
+	return r.storeFor(bucket).AbortMultipartUpload(ctx, bucket, key, uploadID)
+}
+
+func (r *RoutingObjectStore) ListMultipartUploads(ctx context.Context, bucket string) ([]backend.MultipartUploadInfo, error) {
+	return r.storeFor(bucket).ListMultipartUploads(ctx, bucket)
+}